@@ -0,0 +1,453 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// annotations - заметки, выделенные отрывки и закладки одной книги.
+// Списки упорядочены по положению в книге
+type annotations struct {
+	notes      []api.Note
+	highlights []api.Highlight
+	bookmarks  []api.Bookmark
+}
+
+// bookAnnotations возвращает заметки книги или ошибку 404, если книги нет.
+// Если create = false, для книги без заметок возвращается пустой список, который не сохраняется,
+// поэтому при чтении достаточно s.mu.RLock
+func (s *serviceImpl) bookAnnotations(userID, bookID int, create bool) (*annotations, *problem) {
+	if e := s.checkBook(userID, bookID); e != nil {
+		return nil, e
+	}
+	if a := s.annotations[userID][bookID]; a != nil {
+		return a, nil
+	} else if !create {
+		return &annotations{}, nil
+	}
+
+	if _, ok := s.annotations[userID]; !ok {
+		s.annotations[userID] = make(map[int]*annotations)
+	}
+	a := &annotations{}
+	s.annotations[userID][bookID] = a
+	return a, nil
+}
+
+func (s *serviceImpl) nextAnnotationID() int {
+	s.lastAnnotationID++
+	return s.lastAnnotationID
+}
+
+type identified[T any] interface {
+	*T
+	GetID() int
+}
+
+// indexByID ищет в списке заметку с нужным id, возвращает -1 если ее нет
+func indexByID[T any, P identified[T]](list []T, id int) int {
+	return slices.IndexFunc(list, func(v T) bool { return P(&v).GetID() == id })
+}
+
+func notFoundAnnotation(code api.ErrorCode, kind string, id, bookID int) *problem {
+	return err(code, "%s %d not found for book %d", kind, id, bookID)
+}
+
+// Заметки
+
+func compareNotes(a, b api.Note) int {
+	if c := cmp.Compare(a.Page.Or(0), b.Page.Or(0)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func checkNote(req *api.NewNote) error {
+	var c checks
+	c.text("text", &req.Text)
+	if page, ok := req.Page.Get(); ok && page < 1 {
+		c.add("page", "page must be positive, got %d", page)
+	}
+	return c.problem()
+}
+
+func (s *serviceImpl) GetBookNotes(ctx context.Context, params api.GetBookNotesParams) ([]api.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, false)
+	if e != nil {
+		return nil, e
+	}
+	return append([]api.Note{}, a.notes...), nil
+}
+
+func (s *serviceImpl) AddBookNote(ctx context.Context, req *api.NewNote, params api.AddBookNoteParams) (*api.Note, error) {
+	if e := checkNote(req); e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, true)
+	if e != nil {
+		return nil, e
+	}
+	note := api.Note{ID: s.nextAnnotationID(), Text: req.Text, Page: req.Page, Created: time.Now()}
+	a.notes = append(a.notes, note)
+	slices.SortFunc(a.notes, compareNotes)
+	return &note, nil
+}
+
+func (s *serviceImpl) GetBookNote(ctx context.Context, params api.GetBookNoteParams) (*api.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return nil, e
+	} else if i := indexByID(a.notes, params.NoteID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeNoteNotFound, "note", params.NoteID, params.BookID)
+	} else {
+		note := a.notes[i]
+		return &note, nil
+	}
+}
+
+func (s *serviceImpl) UpdateBookNote(ctx context.Context, req *api.NewNote, params api.UpdateBookNoteParams) (*api.Note, error) {
+	if e := checkNote(req); e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return nil, e
+	} else if i := indexByID(a.notes, params.NoteID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeNoteNotFound, "note", params.NoteID, params.BookID)
+	} else {
+		a.notes[i].Text, a.notes[i].Page = req.Text, req.Page
+		note := a.notes[i]
+		slices.SortFunc(a.notes, compareNotes)
+		return &note, nil
+	}
+}
+
+func (s *serviceImpl) RemoveBookNote(ctx context.Context, params api.RemoveBookNoteParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return e
+	} else if i := indexByID(a.notes, params.NoteID); i < 0 {
+		return notFoundAnnotation(api.ErrorCodeNoteNotFound, "note", params.NoteID, params.BookID)
+	} else {
+		a.notes = slices.Delete(a.notes, i, i+1)
+		return nil
+	}
+}
+
+// Выделенные отрывки
+
+func compareHighlights(a, b api.Highlight) int {
+	if c := cmp.Compare(a.LocationStart.Or(0), b.LocationStart.Or(0)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.PageStart.Or(0), b.PageStart.Or(0)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// checkRange проверяет диапазон страниц или позиций, конец по умолчанию совпадает с началом
+func checkRange(what string, start, end *api.OptInt) error {
+	if !start.Set {
+		if end.Set {
+			return invalidField(what+"_end", "%s end is given without start", what)
+		}
+		return nil
+	}
+	if start.Value < 1 {
+		return invalidField(what+"_start", "%s must be positive, got %d", what, start.Value)
+	}
+	if !end.Set {
+		*end = *start
+	} else if end.Value < start.Value {
+		return invalidField(what+"_end", "%s range %d-%d ends before it starts", what, start.Value, end.Value)
+	}
+	return nil
+}
+
+// highlightFromRequest проверяет отрывок из запроса, id и время добавления проставляет вызывающий
+func highlightFromRequest(req *api.NewHighlight) (api.Highlight, error) {
+	if e := checkSchema(req); e != nil {
+		return api.Highlight{}, e
+	}
+	var c checks
+	if c.text("text", &req.Text); len(c) > 0 {
+		return api.Highlight{}, c.problem()
+	}
+	h := api.Highlight{
+		Text:          req.Text,
+		PageStart:     req.PageStart,
+		PageEnd:       req.PageEnd,
+		LocationStart: req.LocationStart,
+		LocationEnd:   req.LocationEnd,
+		Color:         req.Color.Or(api.HighlightColorYellow),
+	}
+	if e := checkRange("page", &h.PageStart, &h.PageEnd); e != nil {
+		return api.Highlight{}, e
+	}
+	if e := checkRange("location", &h.LocationStart, &h.LocationEnd); e != nil {
+		return api.Highlight{}, e
+	}
+	return h, nil
+}
+
+// addHighlights добавляет отрывки к книге, которая точно есть на полке. Вызывается под s.mu
+func (s *serviceImpl) addHighlights(userID, bookID int, highlights ...api.Highlight) {
+	a, _ := s.bookAnnotations(userID, bookID, true)
+	for _, h := range highlights {
+		h.ID = s.nextAnnotationID()
+		if h.Color == "" {
+			h.Color = api.HighlightColorYellow
+		}
+		a.highlights = append(a.highlights, h)
+	}
+	slices.SortFunc(a.highlights, compareHighlights)
+}
+
+func (s *serviceImpl) GetBookHighlights(ctx context.Context, params api.GetBookHighlightsParams) ([]api.Highlight, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, false)
+	if e != nil {
+		return nil, e
+	}
+	return append([]api.Highlight{}, a.highlights...), nil
+}
+
+func (s *serviceImpl) AddBookHighlight(ctx context.Context, req *api.NewHighlight, params api.AddBookHighlightParams) (*api.Highlight, error) {
+	h, e := highlightFromRequest(req)
+	if e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, notFound := s.bookAnnotations(params.UserID, params.BookID, true)
+	if notFound != nil {
+		return nil, notFound
+	}
+	h.ID = s.nextAnnotationID()
+	h.Added = api.NewOptDateTime(time.Now())
+	a.highlights = append(a.highlights, h)
+	slices.SortFunc(a.highlights, compareHighlights)
+	return &h, nil
+}
+
+func (s *serviceImpl) GetBookHighlight(ctx context.Context, params api.GetBookHighlightParams) (*api.Highlight, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return nil, e
+	} else if i := indexByID(a.highlights, params.HighlightID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeHighlightNotFound, "highlight", params.HighlightID, params.BookID)
+	} else {
+		h := a.highlights[i]
+		return &h, nil
+	}
+}
+
+func (s *serviceImpl) UpdateBookHighlight(ctx context.Context, req *api.NewHighlight, params api.UpdateBookHighlightParams) (*api.Highlight, error) {
+	h, e := highlightFromRequest(req)
+	if e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, notFound := s.bookAnnotations(params.UserID, params.BookID, false); notFound != nil {
+		return nil, notFound
+	} else if i := indexByID(a.highlights, params.HighlightID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeHighlightNotFound, "highlight", params.HighlightID, params.BookID)
+	} else {
+		h.ID, h.Added = a.highlights[i].ID, a.highlights[i].Added
+		a.highlights[i] = h
+		slices.SortFunc(a.highlights, compareHighlights)
+		return &h, nil
+	}
+}
+
+func (s *serviceImpl) RemoveBookHighlight(ctx context.Context, params api.RemoveBookHighlightParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return e
+	} else if i := indexByID(a.highlights, params.HighlightID); i < 0 {
+		return notFoundAnnotation(api.ErrorCodeHighlightNotFound, "highlight", params.HighlightID, params.BookID)
+	} else {
+		a.highlights = slices.Delete(a.highlights, i, i+1)
+		return nil
+	}
+}
+
+// formatRange печатает диапазон вида "12" или "12-14"
+func formatRange(start, end api.OptInt) string {
+	if end.Or(start.Value) == start.Value {
+		return fmt.Sprint(start.Value)
+	}
+	return fmt.Sprintf("%d–%d", start.Value, end.Value)
+}
+
+// highlightsMarkdown собирает отрывки книги в Markdown документ с цитатами
+func highlightsMarkdown(book *api.Book, highlights []api.Highlight) string {
+	var md strings.Builder
+	fmt.Fprintf(&md, "# %s\n\n*%s*\n", book.Title, book.Author)
+	for _, h := range highlights {
+		md.WriteString("\n")
+		for _, line := range strings.Split(h.Text, "\n") {
+			md.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+
+		var place []string
+		if h.PageStart.Set {
+			place = append(place, "page "+formatRange(h.PageStart, h.PageEnd))
+		}
+		if h.LocationStart.Set {
+			place = append(place, "location "+formatRange(h.LocationStart, h.LocationEnd))
+		}
+		if len(place) > 0 {
+			fmt.Fprintf(&md, "\n— %s\n", strings.Join(place, ", "))
+		}
+	}
+	return md.String()
+}
+
+func (s *serviceImpl) ExportBookHighlights(ctx context.Context, params api.ExportBookHighlightsParams) (api.ExportBookHighlightsOK, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, false)
+	if e != nil {
+		return api.ExportBookHighlightsOK{}, e
+	}
+	book := s.users[params.UserID][params.BookID]
+	return api.ExportBookHighlightsOK{Data: strings.NewReader(highlightsMarkdown(&book, a.highlights))}, nil
+}
+
+// Закладки
+
+func compareBookmarks(a, b api.Bookmark) int {
+	if c := cmp.Compare(a.Location.Or(0), b.Location.Or(0)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Page.Or(0), b.Page.Or(0)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func checkBookmark(req *api.NewBookmark) error {
+	var c checks
+	c.optText("title", &req.Title)
+	if !req.Page.Set && !req.Location.Set {
+		c.add("page", "either page or location of the bookmark is required")
+	}
+	if page, ok := req.Page.Get(); ok && page < 1 {
+		c.add("page", "page must be positive, got %d", page)
+	}
+	if location, ok := req.Location.Get(); ok && location < 1 {
+		c.add("location", "location must be positive, got %d", location)
+	}
+	return c.problem()
+}
+
+func (s *serviceImpl) GetBookBookmarks(ctx context.Context, params api.GetBookBookmarksParams) ([]api.Bookmark, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, false)
+	if e != nil {
+		return nil, e
+	}
+	return append([]api.Bookmark{}, a.bookmarks...), nil
+}
+
+func (s *serviceImpl) AddBookBookmark(ctx context.Context, req *api.NewBookmark, params api.AddBookBookmarkParams) (*api.Bookmark, error) {
+	if e := checkBookmark(req); e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, e := s.bookAnnotations(params.UserID, params.BookID, true)
+	if e != nil {
+		return nil, e
+	}
+	bookmark := api.Bookmark{ID: s.nextAnnotationID(), Title: req.Title, Page: req.Page, Location: req.Location, Added: time.Now()}
+	a.bookmarks = append(a.bookmarks, bookmark)
+	slices.SortFunc(a.bookmarks, compareBookmarks)
+	return &bookmark, nil
+}
+
+func (s *serviceImpl) GetBookBookmark(ctx context.Context, params api.GetBookBookmarkParams) (*api.Bookmark, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return nil, e
+	} else if i := indexByID(a.bookmarks, params.BookmarkID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeBookmarkNotFound, "bookmark", params.BookmarkID, params.BookID)
+	} else {
+		bookmark := a.bookmarks[i]
+		return &bookmark, nil
+	}
+}
+
+func (s *serviceImpl) UpdateBookBookmark(ctx context.Context, req *api.NewBookmark, params api.UpdateBookBookmarkParams) (*api.Bookmark, error) {
+	if e := checkBookmark(req); e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return nil, e
+	} else if i := indexByID(a.bookmarks, params.BookmarkID); i < 0 {
+		return nil, notFoundAnnotation(api.ErrorCodeBookmarkNotFound, "bookmark", params.BookmarkID, params.BookID)
+	} else {
+		a.bookmarks[i].Title, a.bookmarks[i].Page, a.bookmarks[i].Location = req.Title, req.Page, req.Location
+		bookmark := a.bookmarks[i]
+		slices.SortFunc(a.bookmarks, compareBookmarks)
+		return &bookmark, nil
+	}
+}
+
+func (s *serviceImpl) RemoveBookBookmark(ctx context.Context, params api.RemoveBookBookmarkParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, e := s.bookAnnotations(params.UserID, params.BookID, false); e != nil {
+		return e
+	} else if i := indexByID(a.bookmarks, params.BookmarkID); i < 0 {
+		return notFoundAnnotation(api.ErrorCodeBookmarkNotFound, "bookmark", params.BookmarkID, params.BookID)
+	} else {
+		a.bookmarks = slices.Delete(a.bookmarks, i, i+1)
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	api "mws/gen_api"
+)
+
+// authorRegistry - общий для всех пользователей справочник авторов. Одного автора узнают
+// по ключу имени, в котором не важны регистр, порядок слов и запись кириллицей или латиницей
+type authorRegistry struct {
+	byKey   map[string]int
+	authors map[int]*api.Author
+	lastID  int
+}
+
+func newAuthorRegistry() *authorRegistry {
+	return &authorRegistry{byKey: make(map[string]int), authors: make(map[int]*api.Author)}
+}
+
+// resolve возвращает id автора с таким именем, заводя его при первой встрече. Вызывается под s.mu
+func (r *authorRegistry) resolve(name string) int {
+	name = strings.TrimSpace(name)
+	key := authorKey(name)
+	if id, ok := r.byKey[key]; ok {
+		if author := r.authors[id]; !slices.Contains(author.Variants, name) {
+			author.Variants = append(slices.Clone(author.Variants), name)
+		}
+		return id
+	}
+
+	r.lastID++
+	r.byKey[key] = r.lastID
+	r.authors[r.lastID] = &api.Author{ID: r.lastID, Name: name, Variants: []string{name}}
+	return r.lastID
+}
+
+// find ищет авторов, в имени которых есть все слова запроса. Вызывается под s.mu
+func (r *authorRegistry) find(name string) []api.Author {
+	query := strings.Fields(authorKey(name))
+	found := []api.Author{}
+	for key, id := range r.byKey {
+		words := strings.Fields(key)
+		if len(query) > 0 && !slices.ContainsFunc(query, func(w string) bool { return !slices.Contains(words, w) }) {
+			found = append(found, *r.authors[id])
+		}
+	}
+	slices.SortFunc(found, func(a, b api.Author) int { return cmp.Compare(a.Name, b.Name) })
+	return found
+}
+
+// транслитерация, из которой потом убираются различия популярных систем
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// latinFolds сводит разные записи одних звуков к одной: Fyodor и Fedor, Dostoyevsky и Dostoevskii,
+// Tchekhov и Chekhov
+var latinFolds = strings.NewReplacer("tch", "ch", "yo", "e", "ye", "e", "jo", "e", "je", "e", "kh", "h", "w", "v")
+
+// окончания -ий, -ый в разных системах пишут как -iy, -ij, -ii, -y
+var endingFolds = []string{"iy", "yi", "ij", "ii", "y", "j"}
+
+func foldName(word string) string {
+	var latin strings.Builder
+	for _, r := range word {
+		if s, ok := cyrillicToLatin[r]; ok {
+			latin.WriteString(s)
+		} else {
+			latin.WriteRune(r)
+		}
+	}
+	folded := latinFolds.Replace(latin.String())
+	for _, ending := range endingFolds {
+		if strings.HasSuffix(folded, ending) {
+			return strings.TrimSuffix(folded, ending) + "i"
+		}
+	}
+	return folded
+}
+
+// authorKey нормализует имя: "Достоевский, Фёдор" и "Fyodor Dostoevsky" дают один ключ
+func authorKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return !unicode.IsLetter(r) })
+	for i, w := range words {
+		words[i] = foldName(w)
+	}
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// запятую как разделитель не используем, ей отделяют фамилию от имени
+var authorSeparator = regexp.MustCompile(`\s*[;&]\s*|\s+(?:and|и)\s+`)
+
+// splitAuthors делит строку с несколькими авторами на имена
+func splitAuthors(author string) []string {
+	var names []string
+	for _, name := range authorSeparator.Split(author, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// contributorsFromRequest переносит участников из запроса, если автор не указан, он собирается из них
+func contributorsFromRequest(book *api.Book, req []api.NewContributor) {
+	var authors []string
+	for _, c := range req {
+		role := c.Role.Or(api.ContributorRoleAuthor)
+		book.Contributors = append(book.Contributors, api.Contributor{Name: strings.TrimSpace(c.Name), Role: role})
+		if role == api.ContributorRoleAuthor {
+			authors = append(authors, strings.TrimSpace(c.Name))
+		}
+	}
+	if book.Author == "" {
+		book.Author = strings.Join(authors, ", ")
+	}
+}
+
+// linkAuthors связывает участников книги со справочником авторов, если участников нет,
+// они берутся из строки автора. Вызывается под s.mu
+func (s *serviceImpl) linkAuthors(book *api.Book) {
+	contributors := slices.Clone(book.Contributors)
+	if len(contributors) == 0 {
+		for _, name := range splitAuthors(book.Author) {
+			contributors = append(contributors, api.Contributor{Name: name, Role: api.ContributorRoleAuthor})
+		}
+	}
+	for i := range contributors {
+		contributors[i].AuthorID = s.authors.resolve(contributors[i].Name)
+	}
+	book.Contributors = contributors
+}
+
+func (s *serviceImpl) FindAuthors(ctx context.Context, params api.FindAuthorsParams) ([]api.Author, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.authors.find(params.Name), nil
+}
+
+func (s *serviceImpl) GetAuthor(ctx context.Context, params api.GetAuthorParams) (*api.Author, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if author, ok := s.authors.authors[params.AuthorID]; ok {
+		found := *author
+		return &found, nil
+	}
+	return nil, err(api.ErrorCodeAuthorNotFound, "author %d not found", params.AuthorID)
+}
+
+func (s *serviceImpl) GetUserBooksByAuthor(ctx context.Context, params api.GetUserBooksByAuthorParams) ([]api.Book, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.authors.authors[params.AuthorID]; !ok {
+		return nil, err(api.ErrorCodeAuthorNotFound, "author %d not found", params.AuthorID)
+	}
+	books := []api.Book{}
+	for _, book := range s.users[params.UserID] {
+		if slices.ContainsFunc(book.Contributors, func(c api.Contributor) bool {
+			return c.AuthorID == params.AuthorID && (!params.Role.Set || c.Role == params.Role.Value)
+		}) {
+			books = append(books, s.present(params.UserID, book))
+		}
+	}
+	// сначала ранние книги, книги без даты в конце
+	slices.SortFunc(books, func(a, b api.Book) int {
+		if a.Published.Set != b.Published.Set {
+			if a.Published.Set {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(a.Published.Value.Compare(b.Published.Value), cmp.Compare(a.ID, b.ID))
+	})
+	return books, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	api "mws/gen_api"
+)
+
+var errNotInCatalog = errors.New("book not found in catalog")
+
+// CatalogProvider ищет сведения о книге по ISBN-13.
+// Если книги нет в каталоге, возвращается errNotInCatalog
+type CatalogProvider interface {
+	Lookup(ctx context.Context, isbn13 string) (catalogEntry, error)
+}
+
+// catalogEntry - сведения об одном издании книги
+type catalogEntry struct {
+	Title     string
+	Author    string
+	Published time.Time
+
+	// серия и формат могут быть не указаны
+	Series       string
+	SeriesNumber float64
+	Format       api.EditionFormat
+	Pages        int
+}
+
+// fileCatalog - каталог, целиком загруженный в память из выгрузки в JSON или CSV,
+// чтобы работать без сети
+type fileCatalog struct {
+	entries map[string]catalogEntry
+}
+
+// catalogRecord - запись выгрузки каталога, ISBN может быть как 10-ти так и 13-ти значным
+type catalogRecord struct {
+	ISBN         string  `json:"isbn"`
+	Title        string  `json:"title"`
+	Author       string  `json:"author"`
+	Published    string  `json:"published"`
+	Series       string  `json:"series"`
+	SeriesNumber float64 `json:"series_number"`
+	Format       string  `json:"format"`
+	Pages        int     `json:"pages"`
+}
+
+// loadFileCatalog читает выгрузку каталога, формат определяется по расширению файла:
+// .json - массив записей, .csv - таблица с заголовком isbn,title,author,published.
+// Колонки series, series_number, format и pages необязательны
+func loadFileCatalog(path string) (*fileCatalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []catalogRecord
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.NewDecoder(f).Decode(&records)
+	case ".csv":
+		records, err = readCatalogCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read catalog %s: %w", path, err)
+	}
+
+	catalog := &fileCatalog{entries: make(map[string]catalogEntry, len(records))}
+	for i, r := range records {
+		isbn10, isbn13 := r.ISBN, ""
+		if len(normalizeISBN(r.ISBN)) == 13 {
+			isbn10, isbn13 = "", r.ISBN
+		}
+		_, isbn13, err := resolveISBN(isbn10, isbn13)
+		if err != nil {
+			return nil, fmt.Errorf("catalog %s, record %d: %w", path, i+1, err)
+		}
+		published, err := time.Parse(time.DateOnly, r.Published)
+		if err != nil {
+			return nil, fmt.Errorf("catalog %s, record %d: %w", path, i+1, err)
+		}
+		format := api.EditionFormat(r.Format)
+		if e := format.Validate(); r.Format != "" && e != nil {
+			return nil, fmt.Errorf("catalog %s, record %d: %w", path, i+1, e)
+		}
+		catalog.entries[isbn13] = catalogEntry{
+			Title:        r.Title,
+			Author:       r.Author,
+			Published:    published,
+			Series:       r.Series,
+			SeriesNumber: r.SeriesNumber,
+			Format:       format,
+			Pages:        r.Pages,
+		}
+	}
+	return catalog, nil
+}
+
+func readCatalogCSV(r io.Reader) ([]catalogRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"isbn", "title", "author", "published"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	optional := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	records := make([]catalogRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		record := catalogRecord{
+			ISBN:      row[columns["isbn"]],
+			Title:     row[columns["title"]],
+			Author:    row[columns["author"]],
+			Published: row[columns["published"]],
+			Series:    optional(row, "series"),
+			Format:    optional(row, "format"),
+		}
+		if number := optional(row, "series_number"); number != "" {
+			if record.SeriesNumber, err = strconv.ParseFloat(number, 64); err != nil {
+				return nil, fmt.Errorf("row %d: series_number: %w", i+2, err)
+			}
+		}
+		if pages := optional(row, "pages"); pages != "" {
+			if record.Pages, err = strconv.Atoi(pages); err != nil {
+				return nil, fmt.Errorf("row %d: pages: %w", i+2, err)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (c *fileCatalog) Lookup(ctx context.Context, isbn13 string) (catalogEntry, error) {
+	if entry, ok := c.entries[isbn13]; ok {
+		return entry, nil
+	}
+	return catalogEntry{}, errNotInCatalog
+}
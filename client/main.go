@@ -2,40 +2,97 @@ package main
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	ht "github.com/ogen-go/ogen/http"
+
 	client "mws/gen_api"
 )
 
-func add(ctx context.Context, c *client.Client, example *client.Book, userID int) {
-	if addedBook, err := c.AddUserBook(ctx, example, client.AddUserBookParams{UserID: userID}); err != nil {
+// serverURL - адрес сервера вместе с префиксом путей, если он задан в настройках сервера
+var serverURL = flag.String("server", cmp.Or(os.Getenv("MWS_SERVER"), "http://localhost:8080"),
+	"server URL with the path prefix, e.g. http://localhost:9090/api, also MWS_SERVER")
+
+// fail прерывает команду. Ошибку сервера печатает по полям problem+json, а не строкой, которую собирает ogen
+func fail(err error) {
+	var problem *client.ErrorStatusCode
+	if !errors.As(err, &problem) {
 		log.Panic(err)
+	}
+	msg := fmt.Sprintf("%s [%s]", problem.Response.Title, problem.Response.Code)
+	if detail, ok := problem.Response.Detail.Get(); ok {
+		msg += ": " + detail
+	}
+	for _, f := range problem.Response.Errors {
+		msg += fmt.Sprintf("\n  %s: %s", f.Field, f.Message)
+	}
+	log.Panic(msg)
+}
+
+// languageClient просит сервер отвечать на языке lang, пустой lang оставляет выбор серверу
+type languageClient struct {
+	lang string
+}
+
+func (c *languageClient) Do(r *http.Request) (*http.Response, error) {
+	if c.lang != "" {
+		r.Header.Set("Accept-Language", c.lang)
+	}
+	return http.DefaultClient.Do(r)
+}
+
+// systemLanguage возвращает язык из настроек локали, например ru из ru_RU.UTF-8
+func systemLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			lang, _, _ := strings.Cut(locale, ".")
+			lang, _, _ = strings.Cut(lang, "_")
+			if lang == "C" || lang == "POSIX" {
+				return ""
+			}
+			return lang
+		}
+	}
+	return ""
+}
+
+func add(ctx context.Context, c *client.Client, example *client.NewBook, userID int) {
+	if addedBook, err := c.AddUserBook(ctx, example, client.AddUserBookParams{UserID: userID}); err != nil {
+		fail(err)
 	} else {
 		fmt.Print("Book added: ")
 		json.NewEncoder(os.Stdout).Encode(addedBook)
 	}
 }
 
-func update(ctx context.Context, c *client.Client, userID, bookID, page int) {
-	if book, err := c.UpdateReadingProgress(ctx,
-		&client.UpdateReadingProgressReq{Page: page},
-		client.UpdateReadingProgressParams{UserID: userID, BookID: bookID}); err != nil {
-		log.Panic(err)
+func update(ctx context.Context, c *client.Client, userID, bookID, page int, unit string) {
+	req := &client.UpdateReadingProgressReq{Page: page}
+	if unit != "" {
+		req.Unit = client.NewOptProgressUnit(client.ProgressUnit(unit))
+	}
+	if book, err := c.UpdateReadingProgress(ctx, req, client.UpdateReadingProgressParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
 	} else {
-		fmt.Printf("Page updated: %d\n", book.(*client.Book).Page)
+		fmt.Printf("Page updated: %d %s\n", book.Page, book.Unit.Or(client.ProgressUnitPage))
 	}
 }
 
 func get(ctx context.Context, c *client.Client, userID, bookID int) {
 	if book, err := c.GetUserBook(ctx, client.GetUserBookParams{UserID: userID, BookID: bookID}); err != nil {
-		log.Panic(err)
+		fail(err)
 	} else {
 		fmt.Printf("%d's Book %d: ", userID, bookID)
 		json.NewEncoder(os.Stdout).Encode(book)
@@ -43,15 +100,19 @@ func get(ctx context.Context, c *client.Client, userID, bookID int) {
 }
 
 func remove(ctx context.Context, c *client.Client, userID, bookID int) {
-	if _, err := c.RemoveUserBook(ctx, client.RemoveUserBookParams{UserID: userID, BookID: bookID}); err != nil {
-		log.Panic(err)
+	if err := c.RemoveUserBook(ctx, client.RemoveUserBookParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
 	} else {
 		fmt.Println("Book removed")
 	}
 }
 
-func list(ctx context.Context, c *client.Client, userID int) {
-	if books, err := c.GetUserBooks(ctx, client.GetUserBooksParams{UserID: userID}); err != nil {
+func list(ctx context.Context, c *client.Client, userID int, shelf string) {
+	params := client.GetUserBooksParams{UserID: userID}
+	if shelf != "" {
+		params.Shelf = client.NewOptString(shelf)
+	}
+	if books, err := c.GetUserBooks(ctx, params); err != nil {
 		log.Fatal(err)
 	} else {
 		fmt.Println("Books:")
@@ -61,8 +122,345 @@ func list(ctx context.Context, c *client.Client, userID int) {
 	}
 }
 
+func shelves(ctx context.Context, c *client.Client, userID int) {
+	if found, err := c.GetUserShelves(ctx, client.GetUserShelvesParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Shelves:")
+		for _, sh := range found {
+			fmt.Printf(" - #%d '%s' (%d books)\n", sh.ID, sh.Name, sh.Books)
+		}
+	}
+}
+
+func addShelf(ctx context.Context, c *client.Client, userID int, name string) {
+	if res, err := c.AddUserShelf(ctx, &client.NewShelf{Name: name}, client.AddUserShelfParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func shelve(ctx context.Context, c *client.Client, userID, shelfID, bookID int) {
+	if err := c.AddBookToShelf(ctx, client.AddBookToShelfParams{UserID: userID, ShelfID: shelfID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Book is on the shelf")
+	}
+}
+
+func queue(ctx context.Context, c *client.Client, userID int) {
+	if items, err := c.GetReadingQueue(ctx, client.GetReadingQueueParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Up next:")
+		for _, item := range items {
+			fmt.Printf(" %d. '%s' (%d)\n", item.Position, item.Book.Title, item.Book.ID)
+		}
+	}
+}
+
+func next(ctx context.Context, c *client.Client, userID int) {
+	if book, err := c.PopReadingQueue(ctx, client.PopReadingQueueParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Printf("Now reading '%s'\n", book.Title)
+	}
+}
+
+func reread(ctx context.Context, c *client.Client, userID, bookID int) {
+	if book, err := c.StartReread(ctx, client.StartRereadParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Printf("Started read-through #%d of '%s'\n", len(book.Reads), book.Title)
+	}
+}
+
+func stats(ctx context.Context, c *client.Client, userID int, tz string) {
+	params := client.GetUserStatsParams{UserID: userID}
+	if tz != "" {
+		params.Tz = client.NewOptString(tz)
+	}
+	if s, err := c.GetUserStats(ctx, params); err != nil {
+		fail(err)
+	} else {
+		fmt.Printf("This year: %d books, %d pages\n", s.CurrentYear.Books, s.CurrentYear.Pages)
+		for _, y := range s.FinishedByYear {
+			fmt.Printf(" %d: %d books\n", y.Year, y.Books)
+		}
+		fmt.Printf("Pages per day: %.1f\n", s.PagesPerDay)
+		if days, ok := s.DaysPerBook.Get(); ok {
+			fmt.Printf("Days per book: %.1f\n", days)
+		}
+		for _, a := range s.TopAuthors {
+			fmt.Printf(" - %s: %d books\n", a.Name, a.Books)
+		}
+	}
+}
+
+func report(ctx context.Context, c *client.Client, userID, year int) {
+	page, err := c.GetYearReport(ctx, client.GetYearReportParams{UserID: userID, Year: year})
+	if err != nil {
+		fail(err)
+	}
+	path := fmt.Sprintf("report-%d.html", year)
+	f, err := os.Create(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, page); err != nil {
+		fail(err)
+	}
+	fmt.Println("Saved to", path)
+}
+
+func plan(ctx context.Context, c *client.Client, userID, bookID int, deadline time.Time, restDays []client.Weekday) {
+	req := &client.NewReadingPlan{Deadline: deadline, RestDays: restDays}
+	if p, err := c.SetReadingPlan(ctx, req, client.SetReadingPlanParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		for _, day := range p.Days {
+			fmt.Printf(" %s: %s %d to %d\n", day.Date.Format(time.DateOnly), p.Unit, day.From, day.To)
+		}
+	}
+}
+
+func clubProgress(ctx context.Context, c *client.Client, clubID int) {
+	if members, err := c.GetClubProgress(ctx, client.GetClubProgressParams{ClubID: clubID}); err != nil {
+		fail(err)
+	} else {
+		for _, m := range members {
+			fmt.Printf(" user %d: %3.0f%%\n", m.UserID, m.Progress*100)
+		}
+	}
+}
+
+func follow(ctx context.Context, c *client.Client, userID, followeeID int) {
+	if err := c.FollowUser(ctx, client.FollowUserParams{UserID: userID, FolloweeID: followeeID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Printf("User %d follows user %d\n", userID, followeeID)
+	}
+}
+
+func feed(ctx context.Context, c *client.Client, userID int) {
+	if page, err := c.GetFeed(ctx, client.GetFeedParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		for _, a := range page.Items {
+			fmt.Printf(" %s user %d %s '%s'", a.At.Format(time.DateTime), a.UserID, a.Type, a.Title)
+			if rating, ok := a.Rating.Get(); ok {
+				fmt.Printf(" %.1f", float64(rating))
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func locale(ctx context.Context, c *client.Client, userID int, lang string) {
+	if res, err := c.SetLocale(ctx, &client.Locale{Language: client.Language(lang)}, client.SetLocaleParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Printf("Errors for user %d are in %s when the language is not chosen\n", userID, res.Language)
+	}
+}
+
+func recommend(ctx context.Context, c *client.Client, userID int) {
+	recommendations, err := c.GetRecommendations(ctx, client.GetRecommendationsParams{UserID: userID})
+	if err != nil {
+		fail(err)
+	}
+	fmt.Println("Readers of your books also read:")
+	for _, r := range recommendations {
+		fmt.Printf(" - '%s' by %s (%d)\n", r.Title, r.Author, r.BookID)
+	}
+}
+
+func booksByAuthor(ctx context.Context, c *client.Client, userID int, name string) {
+	authors, err := c.FindAuthors(ctx, client.FindAuthorsParams{Name: name})
+	if err != nil {
+		fail(err)
+	}
+	for _, author := range authors {
+		books, err := c.GetUserBooksByAuthor(ctx, client.GetUserBooksByAuthorParams{UserID: userID, AuthorID: author.ID})
+		if err != nil {
+			fail(err)
+		} else if len(books) > 0 {
+			fmt.Printf("%s:\n", author.Name)
+			for _, b := range books {
+				fmt.Printf(" - '%s' (%d)\n", b.Title, b.ID)
+			}
+		}
+	}
+}
+
+func search(ctx context.Context, c *client.Client, userID int, query string) {
+	if found, err := c.SearchUserBooks(ctx, client.SearchUserBooksParams{UserID: userID, Q: query}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Found:")
+		for _, r := range found {
+			fmt.Printf(" - %d: '%s' by %s (score %.2f)\n", r.Book.ID, r.Book.Title, r.Book.Author, r.Score)
+		}
+	}
+}
+
+func export(ctx context.Context, c *client.Client, userID int, format string) {
+	if res, err := c.ExportUserBooks(ctx, client.ExportUserBooksParams{
+		UserID: userID,
+		Format: client.NewOptExportUserBooksFormat(client.ExportUserBooksFormat(format)),
+	}); err != nil {
+		fail(err)
+	} else if r, ok := res.(io.Reader); ok {
+		io.Copy(os.Stdout, r)
+	}
+}
+
+func importFile(ctx context.Context, c *client.Client, userID int, path string, onConflict string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+
+	var req client.ImportUserBooksReq = &client.ImportUserBooksReqApplicationXNdjson{Data: f}
+	if strings.HasSuffix(path, ".csv") {
+		req = &client.ImportUserBooksReqTextCsv{Data: f}
+	}
+	params := client.ImportUserBooksParams{UserID: userID}
+	if onConflict != "" {
+		params.OnConflict = client.NewOptConflictPolicy(client.ConflictPolicy(onConflict))
+	}
+	if res, err := c.ImportUserBooks(ctx, req, params); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func importGoodreads(ctx context.Context, c *client.Client, userID int, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+
+	if res, err := c.ImportGoodreads(ctx, client.ImportGoodreadsReq{Data: f}, client.ImportGoodreadsParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func importKindle(ctx context.Context, c *client.Client, userID int, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+
+	if res, err := c.ImportKindleClippings(ctx, client.ImportKindleClippingsReq{Data: f}, client.ImportKindleClippingsParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func addEpub(ctx context.Context, c *client.Client, userID int, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+
+	req := &client.AddUserBookEpubReq{File: ht.MultipartFile{Name: filepath.Base(path), File: f}}
+	if res, err := c.AddUserBookEpub(ctx, req, client.AddUserBookEpubParams{UserID: userID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Print("Book added: ")
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func putCover(ctx context.Context, c *client.Client, userID, bookID int, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fail(err)
+	}
+	defer f.Close()
+
+	var req client.PutBookCoverReq = &client.PutBookCoverReqImageJpeg{Data: f}
+	if strings.HasSuffix(strings.ToLower(path), ".png") {
+		req = &client.PutBookCoverReqImagePNG{Data: f}
+	}
+	if res, err := c.PutBookCover(ctx, req, client.PutBookCoverParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func highlights(ctx context.Context, c *client.Client, userID, bookID int) {
+	if found, err := c.GetBookHighlights(ctx, client.GetBookHighlightsParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Highlights:")
+		for _, h := range found {
+			fmt.Printf(" - #%d [%d-%d] %s\n", h.ID, h.LocationStart.Or(0), h.LocationEnd.Or(0), h.Text)
+		}
+	}
+}
+
+func exportHighlights(ctx context.Context, c *client.Client, userID, bookID int) {
+	if md, err := c.ExportBookHighlights(ctx, client.ExportBookHighlightsParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		io.Copy(os.Stdout, md.Data)
+	}
+}
+
+func addNote(ctx context.Context, c *client.Client, userID, bookID int, text string) {
+	if res, err := c.AddBookNote(ctx, &client.NewNote{Text: text}, client.AddBookNoteParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func notes(ctx context.Context, c *client.Client, userID, bookID int) {
+	if found, err := c.GetBookNotes(ctx, client.GetBookNotesParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		fmt.Println("Notes:")
+		for _, n := range found {
+			fmt.Printf(" - #%d (page %d) %s\n", n.ID, n.Page.Or(0), n.Text)
+		}
+	}
+}
+
+func addBookmark(ctx context.Context, c *client.Client, userID, bookID, page int) {
+	if res, err := c.AddBookBookmark(ctx, &client.NewBookmark{Page: client.NewOptInt(page)}, client.AddBookBookmarkParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
+func review(ctx context.Context, c *client.Client, userID, bookID int, rating float64, text string) {
+	req := &client.NewReview{Rating: client.Rating(rating)}
+	if text != "" {
+		req.Text = client.NewOptString(text)
+	}
+	if res, err := c.PutBookReview(ctx, req, client.PutBookReviewParams{UserID: userID, BookID: bookID}); err != nil {
+		fail(err)
+	} else {
+		json.NewEncoder(os.Stdout).Encode(res)
+	}
+}
+
 func test() {
-	c, err := client.NewClient("http://localhost:8080")
+	c, err := client.NewClient(*serverURL)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -72,24 +470,26 @@ func test() {
 	bookID := 1234
 
 	date, _ := time.Parse(time.DateOnly, "1957-11-23")
-	example := &client.Book{
+	example := &client.NewBook{
 		ID:        bookID,
-		Title:     "Доктор Живаго",
-		Author:    "Борис Пастернак",
-		Published: date,
-		Page:      2,
+		Title:     client.NewOptString("Доктор Живаго"),
+		Author:    client.NewOptString("Борис Пастернак"),
+		Published: client.NewOptDate(date),
+		Page:      client.NewOptInt(2),
 	}
 
 	add(ctx, c, example, userID)
-	list(ctx, c, userID)
-	update(ctx, c, userID, bookID, 25)
+	list(ctx, c, userID, "")
+	update(ctx, c, userID, bookID, 25, "")
 	get(ctx, c, userID, bookID)
 	remove(ctx, c, userID, bookID)
 
-	if res, err := c.GetUserBook(ctx, client.GetUserBookParams{UserID: userID, BookID: bookID}); err != nil {
+	// книга уже удалена, так что сервер ответит ошибкой
+	var problem *client.ErrorStatusCode
+	if _, err := c.GetUserBook(ctx, client.GetUserBookParams{UserID: userID, BookID: bookID}); !errors.As(err, &problem) {
 		log.Fatal(err)
 	} else {
-		json.NewEncoder(os.Stdout).Encode(res.(*client.Error)) // явно сконвертим
+		json.NewEncoder(os.Stdout).Encode(problem.Response)
 	}
 }
 
@@ -97,15 +497,45 @@ func printHelp() {
 	fmt.Println(`Available commands:
     help                        - show this help
     exit                        - exit program
-    list <userID>               - list user's books
+    lang [language]             - show or change the language of errors, e.g. ru or en, the default comes from LANG
+    locale <userID> <en|ru>     - set the language of errors about the user for clients that don't choose one
+    list <userID> [shelf]       - list user's books, only the ones on the shelf if it is given
+    shelves <userID>            - list user's shelves
+    queue <userID>              - list books the user wants to read in order
+    next <userID>               - start reading the first book of the queue
+    shelf <userID> <name>       - create a shelf
+    shelve <userID> <shelfID> <bookID> - put the book on the shelf
+    search <userID> <query>     - search user's books by title and author
     get <userID> <bookID>       - get book info
     remove <userID> <bookID>    - remove book
-    update <userID> <bookID> <page> - update reading progress
-    add <userID> <title>        - add new book`)
+    update <userID> <bookID> <page> [unit] - update reading progress, unit is page, location, percent or seconds
+    reread <userID> <bookID>    - start reading the read book again
+    plan <userID> <bookID> <YYYY-MM-DD> [rest days] - plan to finish the book by the date, rest days are comma-separated, e.g. saturday,sunday
+    stats <userID> [time zone]  - show reading statistics
+    club <clubID>               - show progress of the club members on the current book
+    follow <userID> <followeeID> - follow another user
+    feed <userID>               - show activity of followed users
+    recommend <userID>          - recommend books other readers of user's books read
+    report <userID> <year>      - save year in review to report-<year>.html
+    export <userID> <csv|jsonl> - print user's books
+    import <userID> <file> [skip|overwrite|fail] - import books from .csv or .jsonl file
+    epub <userID> <file>        - add book with metadata from EPUB file
+    goodreads <userID> <file>   - import Goodreads library export
+    kindle <userID> <file>      - import highlights from Kindle "My Clippings.txt"
+    highlights <userID> <bookID> [md] - list highlights of the book or export them as Markdown
+    notes <userID> <bookID>     - list notes on the book
+    note <userID> <bookID> <text> - add note on the book
+    bookmark <userID> <bookID> <page> - add bookmark
+    review <userID> <bookID> <rating> [text] - rate read book from 1 to 5 with half-star steps
+    cover <userID> <bookID> <file> - upload JPEG or PNG cover of the book
+    add <userID> <bookID> <title> <author> - add new book, title is one word
+    author <userID> <name>      - list user's books by the author
+    isbn <userID> <bookID> <isbn> - add new book by ISBN from the catalog`)
 }
 
 func interactive() {
-	serv, err := client.NewClient("http://localhost:8080")
+	lang := &languageClient{lang: systemLanguage()}
+	serv, err := client.NewClient(*serverURL, client.WithClient(lang))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -159,15 +589,120 @@ func interactive() {
 				printHelp()
 			case "exit":
 				exit = true
+			case "lang":
+				if argStr != "" {
+					lang.lang = argStr
+				}
+				if lang.lang == "" {
+					fmt.Println("Language is chosen by the server")
+				} else {
+					fmt.Println("Language:", lang.lang)
+				}
+			case "locale":
+				if args, ok := parse("wrong format, expected: locale <userID> <en|ru>", args, "is"); ok {
+					locale(ctx, serv, args[0].(int), args[1].(string))
+				}
 			case "add":
 				args := strings.SplitN(argStr, " ", 4)
 				if args, ok := parse("wrong format, expected: add <userID> <bookID> <book title> <author name>", args, "iiss"); ok {
-					book := &client.Book{Page: 1, ID: args[1].(int), Title: args[2].(string), Author: args[2].(string), Published: time.Now()}
+					book := &client.NewBook{ID: args[1].(int), Title: client.NewOptString(args[2].(string)), Author: client.NewOptString(args[3].(string)), Published: client.NewOptDate(time.Now())}
+					add(ctx, serv, book, args[0].(int))
+				}
+			case "isbn":
+				if args, ok := parse("wrong format, expected: isbn <userID> <bookID> <isbn>", args, "iis"); ok {
+					book := &client.NewBook{ID: args[1].(int)}
+					if isbn := args[2].(string); len(strings.ReplaceAll(isbn, "-", "")) == 10 {
+						book.Isbn10 = client.NewOptString(isbn)
+					} else {
+						book.Isbn13 = client.NewOptString(isbn)
+					}
 					add(ctx, serv, book, args[0].(int))
 				}
 			case "list":
-				if args, ok := parse("wrong format, expected: list <userID>", args, "i"); ok {
-					list(ctx, serv, args[0].(int))
+				shelf := ""
+				if args := strings.SplitN(argStr, " ", 2); len(args) > 1 {
+					shelf = args[1]
+				}
+				if args, ok := parse("wrong format, expected: list <userID> [shelf]", args, "i"); ok {
+					list(ctx, serv, args[0].(int), shelf)
+				}
+			case "shelves":
+				if args, ok := parse("wrong format, expected: shelves <userID>", args, "i"); ok {
+					shelves(ctx, serv, args[0].(int))
+				}
+			case "shelf":
+				args := strings.SplitN(argStr, " ", 2)
+				if args, ok := parse("wrong format, expected: shelf <userID> <name>", args, "is"); ok {
+					addShelf(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "shelve":
+				if args, ok := parse("wrong format, expected: shelve <userID> <shelfID> <bookID>", args, "iii"); ok {
+					shelve(ctx, serv, args[0].(int), args[1].(int), args[2].(int))
+				}
+			case "queue":
+				if args, ok := parse("wrong format, expected: queue <userID>", args, "i"); ok {
+					queue(ctx, serv, args[0].(int))
+				}
+			case "next":
+				if args, ok := parse("wrong format, expected: next <userID>", args, "i"); ok {
+					next(ctx, serv, args[0].(int))
+				}
+			case "reread":
+				if args, ok := parse("wrong format, expected: reread <userID> <bookID>", args, "ii"); ok {
+					reread(ctx, serv, args[0].(int), args[1].(int))
+				}
+			case "stats":
+				tz := ""
+				if len(args) > 1 {
+					tz = args[1]
+				}
+				if args, ok := parse("wrong format, expected: stats <userID> [time zone]", args, "i"); ok {
+					stats(ctx, serv, args[0].(int), tz)
+				}
+			case "plan":
+				var restDays []client.Weekday
+				if len(args) > 3 {
+					for _, day := range strings.Split(args[3], ",") {
+						restDays = append(restDays, client.Weekday(day))
+					}
+				}
+				if args, ok := parse("wrong format, expected: plan <userID> <bookID> <YYYY-MM-DD> [rest days]", args, "iis"); ok {
+					deadline, err := time.Parse(time.DateOnly, args[2].(string))
+					if err != nil {
+						fmt.Println("wrong date, expected YYYY-MM-DD")
+						break
+					}
+					plan(ctx, serv, args[0].(int), args[1].(int), deadline, restDays)
+				}
+			case "club":
+				if args, ok := parse("wrong format, expected: club <clubID>", args, "i"); ok {
+					clubProgress(ctx, serv, args[0].(int))
+				}
+			case "follow":
+				if args, ok := parse("wrong format, expected: follow <userID> <followeeID>", args, "ii"); ok {
+					follow(ctx, serv, args[0].(int), args[1].(int))
+				}
+			case "feed":
+				if args, ok := parse("wrong format, expected: feed <userID>", args, "i"); ok {
+					feed(ctx, serv, args[0].(int))
+				}
+			case "recommend":
+				if args, ok := parse("wrong format, expected: recommend <userID>", args, "i"); ok {
+					recommend(ctx, serv, args[0].(int))
+				}
+			case "report":
+				if args, ok := parse("wrong format, expected: report <userID> <year>", args, "ii"); ok {
+					report(ctx, serv, args[0].(int), args[1].(int))
+				}
+			case "author":
+				args := strings.SplitN(argStr, " ", 2)
+				if args, ok := parse("wrong format, expected: author <userID> <name>", args, "is"); ok {
+					booksByAuthor(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "search":
+				args := strings.SplitN(argStr, " ", 2)
+				if args, ok := parse("wrong format, expected: search <userID> <query>", args, "is"); ok {
+					search(ctx, serv, args[0].(int), args[1].(string))
 				}
 			case "get":
 				if args, ok := parse("wrong format, expected: get <userID> <bookID>", args, "ii"); ok {
@@ -178,8 +713,76 @@ func interactive() {
 					remove(ctx, serv, args[0].(int), args[1].(int))
 				}
 			case "update":
-				if args, ok := parse("wrong format, expected: update <userID> <bookID> <page>", args, "iii"); ok {
-					update(ctx, serv, args[0].(int), args[1].(int), args[2].(int))
+				unit := ""
+				if len(args) > 3 {
+					unit = args[3]
+				}
+				if args, ok := parse("wrong format, expected: update <userID> <bookID> <page> [unit]", args, "iii"); ok {
+					update(ctx, serv, args[0].(int), args[1].(int), args[2].(int), unit)
+				}
+			case "export":
+				if args, ok := parse("wrong format, expected: export <userID> <csv|jsonl>", args, "is"); ok {
+					export(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "import":
+				onConflict := ""
+				if len(args) > 2 {
+					onConflict = args[2]
+				}
+				if args, ok := parse("wrong format, expected: import <userID> <file> [skip|overwrite|fail]", args, "is"); ok {
+					importFile(ctx, serv, args[0].(int), args[1].(string), onConflict)
+				}
+			case "epub":
+				if args, ok := parse("wrong format, expected: epub <userID> <file>", args, "is"); ok {
+					addEpub(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "goodreads":
+				if args, ok := parse("wrong format, expected: goodreads <userID> <file>", args, "is"); ok {
+					importGoodreads(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "kindle":
+				if args, ok := parse("wrong format, expected: kindle <userID> <file>", args, "is"); ok {
+					importKindle(ctx, serv, args[0].(int), args[1].(string))
+				}
+			case "cover":
+				if args, ok := parse("wrong format, expected: cover <userID> <bookID> <file>", args, "iis"); ok {
+					putCover(ctx, serv, args[0].(int), args[1].(int), args[2].(string))
+				}
+			case "highlights":
+				markdown := len(args) > 2 && args[2] == "md"
+				if args, ok := parse("wrong format, expected: highlights <userID> <bookID> [md]", args, "ii"); ok {
+					if markdown {
+						exportHighlights(ctx, serv, args[0].(int), args[1].(int))
+					} else {
+						highlights(ctx, serv, args[0].(int), args[1].(int))
+					}
+				}
+			case "notes":
+				if args, ok := parse("wrong format, expected: notes <userID> <bookID>", args, "ii"); ok {
+					notes(ctx, serv, args[0].(int), args[1].(int))
+				}
+			case "note":
+				args := strings.SplitN(argStr, " ", 3)
+				if args, ok := parse("wrong format, expected: note <userID> <bookID> <text>", args, "iis"); ok {
+					addNote(ctx, serv, args[0].(int), args[1].(int), args[2].(string))
+				}
+			case "bookmark":
+				if args, ok := parse("wrong format, expected: bookmark <userID> <bookID> <page>", args, "iii"); ok {
+					addBookmark(ctx, serv, args[0].(int), args[1].(int), args[2].(int))
+				}
+			case "review":
+				args := strings.SplitN(argStr, " ", 4)
+				text := ""
+				if len(args) > 3 {
+					text = args[3]
+				}
+				if args, ok := parse("wrong format, expected: review <userID> <bookID> <rating> [text]", args, "iis"); ok {
+					rating, err := strconv.ParseFloat(args[2].(string), 64)
+					if err != nil {
+						fmt.Println("wrong rating, expected number from 1 to 5")
+						break
+					}
+					review(ctx, serv, args[0].(int), args[1].(int), rating, text)
 				}
 			default:
 				printHelp()
@@ -191,9 +794,42 @@ func interactive() {
 	}
 }
 
+// runImport выполняет импорт из командной строки: client goodreads|kindle <userID> <file>
+func runImport(kind string, args []string) {
+	if len(args) != 2 {
+		log.Fatalf("usage: client %s <userID> <file>", kind)
+	}
+	userID, err := strconv.Atoi(args[0])
+	if err != nil {
+		log.Fatalf("invalid user id %q", args[0])
+	}
+	c, err := client.NewClient(*serverURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// fail уже напечатал ошибку через log.Panic, стек паники пользователю не нужен
+	defer func() {
+		if r := recover(); r != nil {
+			os.Exit(1)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if kind == "goodreads" {
+		importGoodreads(ctx, c, userID, args[1])
+	} else {
+		importKindle(ctx, c, userID, args[1])
+	}
+}
+
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "i" {
+	flag.Parse()
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "i" {
 		interactive()
+	} else if len(args) > 0 && (args[0] == "goodreads" || args[0] == "kindle") {
+		runImport(args[0], args[1:])
 	} else {
 		test()
 	}
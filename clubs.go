@@ -0,0 +1,271 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Клуб читает одну книгу за раз. Прогресс участников не копируется в клуб, а берется с их полок,
+// клуб хранит только отметки о пройденных всеми вехах
+
+type club struct {
+	name    string
+	owner   int
+	members map[int]struct{}
+	invited map[int]struct{}
+	// 0 - книга еще не выбрана
+	bookID     int
+	milestones []api.Milestone
+	events     []api.ClubEvent
+}
+
+// вехи по умолчанию - четверти книги
+var defaultMilestones = []api.NewMilestone{
+	{Name: "A quarter", Percent: 25},
+	{Name: "Halfway", Percent: 50},
+	{Name: "Three quarters", Percent: 75},
+	{Name: "Finished", Percent: 100},
+}
+
+func (c *club) view(id int) api.Club {
+	view := api.Club{
+		ID:         id,
+		Name:       c.name,
+		Owner:      c.owner,
+		Members:    slices.Sorted(maps.Keys(c.members)),
+		Invited:    slices.Sorted(maps.Keys(c.invited)),
+		Milestones: slices.Clone(c.milestones),
+	}
+	if c.bookID != 0 {
+		view.BookID = api.NewOptInt(c.bookID)
+	}
+	return view
+}
+
+func (c *club) emit(event api.ClubEvent) {
+	event.ID = len(c.events) + 1
+	event.At = time.Now()
+	c.events = append(c.events, event)
+}
+
+// findClub возвращает клуб или ошибку 404. Вызывается под s.mu
+func (s *serviceImpl) findClub(id int) (*club, *problem) {
+	if c, ok := s.clubs[id]; ok {
+		return c, nil
+	}
+	return nil, err(api.ErrorCodeClubNotFound, "club %d not found", id)
+}
+
+// memberProgress берет прогресс участника с его полки. Книга, прочитанная хотя бы раз, считается пройденной
+// до конца, даже если ее перечитывают. Вызывается под s.mu
+func (s *serviceImpl) memberProgress(userID, bookID int) api.MemberProgress {
+	book, ok := s.users[userID][bookID]
+	p := api.MemberProgress{UserID: userID, HasBook: ok}
+	if !ok {
+		return p
+	}
+	unit, _, _ := bookUnit(&book)
+	p.Status = api.NewOptReadingStatus(book.Status)
+	p.Unit = api.NewOptProgressUnit(unit)
+	p.Page = api.NewOptInt(book.Page)
+	if slices.ContainsFunc(book.Reads, func(r api.ReadThrough) bool { return r.Completed }) {
+		p.Progress = 1
+	} else if read, ok := progress(&book); ok {
+		p.Progress = read
+	}
+	return p
+}
+
+// checkMilestones отмечает вехи, которые прошли все участники клуба. Вызывается под s.mu
+func (s *serviceImpl) checkMilestones(c *club) {
+	if c.bookID == 0 {
+		return
+	}
+	slowest := 1.0
+	for member := range c.members {
+		slowest = min(slowest, s.memberProgress(member, c.bookID).Progress)
+	}
+	// погрешность нужна, чтобы 0.5 после перевода из позиции не оказалось меньше 50%
+	percent := int(math.Floor(slowest*100 + 1e-9))
+	for i := range c.milestones {
+		if m := &c.milestones[i]; !m.Reached.Set && m.Percent <= percent {
+			m.Reached = api.NewOptDateTime(time.Now())
+			c.emit(api.ClubEvent{Type: api.ClubEventTypeMilestoneReached, BookID: api.NewOptInt(c.bookID), Milestone: api.NewOptString(m.Name)})
+		}
+	}
+}
+
+// syncClubs проверяет вехи клубов, которые читают эту книгу вместе с пользователем.
+// Вызывается под s.mu после каждого изменения книги
+func (s *serviceImpl) syncClubs(userID, bookID int) {
+	for _, c := range s.clubs {
+		if _, ok := c.members[userID]; ok && c.bookID == bookID {
+			s.checkMilestones(c)
+		}
+	}
+}
+
+func (s *serviceImpl) CreateClub(ctx context.Context, req *api.NewClub) (*api.Club, error) {
+	var check checks
+	if check.text("name", &req.Name); len(check) > 0 {
+		return nil, check.problem()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastClubID++
+	c := &club{name: req.Name, owner: req.Owner, members: map[int]struct{}{req.Owner: {}}, invited: make(map[int]struct{})}
+	s.clubs[s.lastClubID] = c
+	view := c.view(s.lastClubID)
+	return &view, nil
+}
+
+func (s *serviceImpl) GetClub(ctx context.Context, params api.GetClubParams) (*api.Club, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	}
+	view := c.view(params.ClubID)
+	return &view, nil
+}
+
+func (s *serviceImpl) InviteToClub(ctx context.Context, req *api.ClubUser, params api.InviteToClubParams) (*api.Club, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	}
+	if _, ok := c.members[req.UserID]; ok {
+		return nil, err(api.ErrorCodeAlreadyMember, "user %d is a member of the club already", req.UserID)
+	} else if _, ok := c.invited[req.UserID]; ok {
+		return nil, err(api.ErrorCodeAlreadyInvited, "user %d is invited already", req.UserID)
+	}
+	c.invited[req.UserID] = struct{}{}
+	view := c.view(params.ClubID)
+	return &view, nil
+}
+
+func (s *serviceImpl) JoinClub(ctx context.Context, req *api.ClubUser, params api.JoinClubParams) (*api.Club, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	}
+	if _, ok := c.invited[req.UserID]; !ok {
+		return nil, err(api.ErrorCodeNotInvited, "user %d is not invited to the club", req.UserID)
+	}
+	delete(c.invited, req.UserID)
+	c.members[req.UserID] = struct{}{}
+	c.emit(api.ClubEvent{Type: api.ClubEventTypeMemberJoined, UserID: api.NewOptInt(req.UserID)})
+	view := c.view(params.ClubID)
+	return &view, nil
+}
+
+func (s *serviceImpl) LeaveClub(ctx context.Context, params api.LeaveClubParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return e
+	}
+	// приглашенный может отказаться от приглашения тем же запросом
+	if _, ok := c.invited[params.UserID]; ok {
+		delete(c.invited, params.UserID)
+		return nil
+	}
+	if _, ok := c.members[params.UserID]; !ok {
+		return err(api.ErrorCodeMemberNotFound, "user %d is not a member of the club", params.UserID)
+	} else if params.UserID == c.owner {
+		return err(api.ErrorCodeOwnerCannotLeave, "owner of the club can't leave it")
+	}
+	delete(c.members, params.UserID)
+	c.emit(api.ClubEvent{Type: api.ClubEventTypeMemberLeft, UserID: api.NewOptInt(params.UserID)})
+	// без отстающего участника вехи могли оказаться пройдены
+	s.checkMilestones(c)
+	return nil
+}
+
+func (s *serviceImpl) PickClubBook(ctx context.Context, req *api.ClubBook, params api.PickClubBookParams) (*api.Club, error) {
+	requested := req.Milestones
+	if len(requested) == 0 {
+		requested = defaultMilestones
+	}
+	milestones := make([]api.Milestone, 0, len(requested))
+	var check checks
+	for i, m := range requested {
+		check.text(fmt.Sprintf("milestones.%d.name", i), &m.Name)
+		milestones = append(milestones, api.Milestone{Name: m.Name, Percent: m.Percent})
+	}
+	if e := check.problem(); e != nil {
+		return nil, e
+	}
+	slices.SortStableFunc(milestones, func(a, b api.Milestone) int { return cmp.Compare(a.Percent, b.Percent) })
+	for i := 1; i < len(milestones); i++ {
+		if milestones[i-1].Percent == milestones[i].Percent {
+			return nil, invalidField("milestones", "milestones %q and %q are at the same position",
+				milestones[i-1].Name, milestones[i].Name)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	}
+	c.bookID, c.milestones = req.BookID, milestones
+	c.emit(api.ClubEvent{Type: api.ClubEventTypeBookPicked, BookID: api.NewOptInt(req.BookID)})
+	s.checkMilestones(c)
+	view := c.view(params.ClubID)
+	return &view, nil
+}
+
+func (s *serviceImpl) GetClubProgress(ctx context.Context, params api.GetClubProgressParams) ([]api.MemberProgress, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	} else if c.bookID == 0 {
+		return nil, err(api.ErrorCodeNoCurrentBook, "club %d has no current book", params.ClubID)
+	}
+	members := []api.MemberProgress{}
+	for member := range c.members {
+		members = append(members, s.memberProgress(member, c.bookID))
+	}
+	slices.SortFunc(members, func(a, b api.MemberProgress) int {
+		return cmp.Or(cmp.Compare(b.Progress, a.Progress), cmp.Compare(a.UserID, b.UserID))
+	})
+	return members, nil
+}
+
+func (s *serviceImpl) GetClubEvents(ctx context.Context, params api.GetClubEventsParams) ([]api.ClubEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, e := s.findClub(params.ClubID)
+	if e != nil {
+		return nil, e
+	}
+	// id событий клуба идут подряд с единицы
+	after := min(max(params.After.Or(0), 0), len(c.events))
+	return append([]api.ClubEvent{}, c.events[after:]...), nil
+}
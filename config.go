@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/yaml"
+)
+
+// Настройки сервера берутся по возрастанию приоритета: значения по умолчанию, файл из -config или MWS_CONFIG,
+// переменные окружения MWS_*, флаги. Файл - YAML или JSON, JSON тоже читается как YAML
+
+// config - все настройки сервера, поля называются так же, как ключи файла
+type config struct {
+	Listen     string         `yaml:"listen"`
+	PathPrefix string         `yaml:"path_prefix"`
+	Storage    storageConfig  `yaml:"storage"`
+	Catalog    string         `yaml:"catalog"`
+	Timeouts   timeoutsConfig `yaml:"timeouts"`
+	LogLevel   string         `yaml:"log_level"`
+}
+
+// storageConfig - где хранятся обложки, остальные данные сервис держит в памяти
+type storageConfig struct {
+	// fs - в каталоге path, memory - в памяти до перезапуска
+	Backend string `yaml:"backend"`
+	Path    string `yaml:"path"`
+}
+
+// timeoutsConfig - таймауты http.Server, 0 - без ограничения
+type timeoutsConfig struct {
+	ReadHeader time.Duration `yaml:"read_header"`
+	Read       time.Duration `yaml:"read"`
+	Write      time.Duration `yaml:"write"`
+	Idle       time.Duration `yaml:"idle"`
+}
+
+func defaultConfig() config {
+	return config{
+		Listen:  ":8080",
+		Storage: storageConfig{Backend: "fs", Path: "covers"},
+		Timeouts: timeoutsConfig{
+			ReadHeader: 10 * time.Second,
+			Read:       time.Minute,
+			Write:      time.Minute,
+			Idle:       2 * time.Minute,
+		},
+		LogLevel: "info",
+	}
+}
+
+// setting связывает флаг и переменную окружения с полем config. field возвращает указатель
+// на *string или *time.Duration
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	field func(c *config) any
+}
+
+var settings = []setting{
+	{"listen", "MWS_LISTEN", "address to listen on", func(c *config) any { return &c.Listen }},
+	{"path-prefix", "MWS_PATH_PREFIX", "prefix of all API paths, e.g. /api", func(c *config) any { return &c.PathPrefix }},
+	{"storage", "MWS_STORAGE", "storage of cover images: fs or memory", func(c *config) any { return &c.Storage.Backend }},
+	{"storage-path", "MWS_STORAGE_PATH", "directory of the fs storage", func(c *config) any { return &c.Storage.Path }},
+	// -covers - имя флага до появления -storage-path, оставлено, чтобы не сломать скрипты запуска
+	{"covers", "", "same as -storage-path", func(c *config) any { return &c.Storage.Path }},
+	{"catalog", "MWS_CATALOG", "path to a JSON or CSV catalog dump used to fill books by ISBN", func(c *config) any { return &c.Catalog }},
+	{"read-header-timeout", "MWS_READ_HEADER_TIMEOUT", "time to read request headers", func(c *config) any { return &c.Timeouts.ReadHeader }},
+	{"read-timeout", "MWS_READ_TIMEOUT", "time to read the whole request", func(c *config) any { return &c.Timeouts.Read }},
+	{"write-timeout", "MWS_WRITE_TIMEOUT", "time to write the response", func(c *config) any { return &c.Timeouts.Write }},
+	{"idle-timeout", "MWS_IDLE_TIMEOUT", "time to keep an idle connection open", func(c *config) any { return &c.Timeouts.Idle }},
+	{"log-level", "MWS_LOG_LEVEL", "debug, info, warn or error", func(c *config) any { return &c.LogLevel }},
+}
+
+// set разбирает значение настройки из строки
+func (s setting) set(c *config, value string) error {
+	switch field := s.field(c).(type) {
+	case *string:
+		*field = value
+	case *time.Duration:
+		d, e := time.ParseDuration(value)
+		if e != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		*field = d
+	}
+	return nil
+}
+
+func (s setting) get(c *config) string {
+	switch field := s.field(c).(type) {
+	case *string:
+		return *field
+	case *time.Duration:
+		return field.String()
+	}
+	return ""
+}
+
+// loadConfig собирает настройки из файла, окружения и аргументов командной строки.
+// printConfig - запрошен ли вывод итоговых настроек вместо запуска
+func loadConfig(args []string) (cfg config, printConfig bool, e error) {
+	cfg = defaultConfig()
+
+	flags := flag.NewFlagSet("mws", flag.ContinueOnError)
+	path := flags.String("config", os.Getenv("MWS_CONFIG"), "YAML or JSON config file, also MWS_CONFIG")
+	flags.BoolVar(&printConfig, "print-config", false, "print the effective configuration and exit")
+	values := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		usage := s.usage
+		if s.env != "" {
+			usage += ", also " + s.env
+		}
+		values[s.flag] = flags.String(s.flag, s.get(&cfg), usage)
+	}
+	if e := flags.Parse(args); e != nil {
+		return cfg, false, e
+	}
+
+	if *path != "" {
+		if e := cfg.readFile(*path); e != nil {
+			return cfg, false, e
+		}
+	}
+	for _, s := range settings {
+		if s.env == "" {
+			continue
+		}
+		if value, ok := os.LookupEnv(s.env); ok {
+			if e := s.set(&cfg, value); e != nil {
+				return cfg, false, fmt.Errorf("%s: %w", s.env, e)
+			}
+		}
+	}
+	// флаги применяются, только если заданы явно, иначе значение по умолчанию перетерло бы файл и окружение
+	flags.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag == f.Name && e == nil {
+				if err := s.set(&cfg, *values[s.flag]); err != nil {
+					e = fmt.Errorf("-%s: %w", s.flag, err)
+				}
+			}
+		}
+	})
+	if e != nil {
+		return cfg, false, e
+	}
+	return cfg, printConfig, cfg.validate()
+}
+
+// readFile читает настройки из файла, ключи, которых нет в config, считаются ошибкой,
+// иначе опечатка в имени молча оставила бы значение по умолчанию
+func (c *config) readFile(path string) error {
+	f, e := os.Open(path)
+	if e != nil {
+		return fmt.Errorf("read config: %w", e)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if e := decoder.Decode(c); e != nil && !errors.Is(e, io.EOF) {
+		return fmt.Errorf("read config %s: %w", path, e)
+	}
+	return nil
+}
+
+// validate проверяет настройки до запуска и сообщает обо всех ошибках сразу
+func (c *config) validate() error {
+	var errs []error
+	if _, port, e := net.SplitHostPort(c.Listen); e != nil {
+		errs = append(errs, fmt.Errorf("listen: %w", e))
+	} else if n, e := strconv.Atoi(port); e != nil || n < 0 || n > 65535 {
+		errs = append(errs, fmt.Errorf("listen: invalid port %q", port))
+	}
+	if c.PathPrefix != "" && (!strings.HasPrefix(c.PathPrefix, "/") || strings.HasSuffix(c.PathPrefix, "/")) {
+		errs = append(errs, fmt.Errorf("path_prefix: %q must start with / and must not end with /", c.PathPrefix))
+	}
+	switch c.Storage.Backend {
+	case "fs":
+		if c.Storage.Path == "" {
+			errs = append(errs, errors.New("storage.path: required for fs storage"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend: unknown backend %q, expected fs or memory", c.Storage.Backend))
+	}
+	if c.Catalog != "" {
+		if _, e := os.Stat(c.Catalog); e != nil {
+			errs = append(errs, fmt.Errorf("catalog: %w", e))
+		}
+	}
+	for _, t := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"timeouts.read_header", c.Timeouts.ReadHeader},
+		{"timeouts.read", c.Timeouts.Read},
+		{"timeouts.write", c.Timeouts.Write},
+		{"timeouts.idle", c.Timeouts.Idle},
+	} {
+		if t.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: %s is negative", t.name, t.value))
+		}
+	}
+	if _, e := c.logLevel(); e != nil {
+		errs = append(errs, fmt.Errorf("log_level: %w", e))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *config) logLevel() (slog.Level, error) {
+	var level slog.Level
+	e := level.UnmarshalText([]byte(c.LogLevel))
+	return level, e
+}
+
+// print выводит настройки в формате файла, так что вывод можно сохранить и использовать как -config
+func (c *config) print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	if e := encoder.Encode(c); e != nil {
+		return e
+	}
+	return encoder.Close()
+}
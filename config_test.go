@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv убирает MWS_* из окружения теста, после теста t.Setenv вернет прежние значения
+func clearEnv(t *testing.T) {
+	t.Helper()
+	names := []string{"MWS_CONFIG"}
+	for _, s := range settings {
+		if s.env != "" {
+			names = append(names, s.env)
+		}
+	}
+	for _, name := range names {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if e := os.WriteFile(path, []byte(content), 0o644); e != nil {
+		t.Fatal(e)
+	}
+	return path
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	yamlFile := `
+listen: ":7000"
+storage:
+  backend: memory
+timeouts:
+  read: 30s
+log_level: warn
+`
+	jsonFile := `{"listen": ":7000", "storage": {"backend": "memory"}, "timeouts": {"read": "30s"}, "log_level": "warn"}`
+
+	for _, tt := range []struct {
+		name  string
+		file  string
+		env   map[string]string
+		args  []string
+		check func(c config) bool
+	}{
+		{
+			name: "defaults",
+			check: func(c config) bool {
+				return reflect.DeepEqual(c, defaultConfig())
+			},
+		},
+		{
+			name: "YAML file over defaults",
+			file: "mws.yaml:" + yamlFile,
+			check: func(c config) bool {
+				return c.Listen == ":7000" && c.Storage.Backend == "memory" && c.Storage.Path == "covers" &&
+					c.Timeouts.Read == 30*time.Second && c.Timeouts.Write == time.Minute && c.LogLevel == "warn"
+			},
+		},
+		{
+			name: "JSON file over defaults",
+			file: "mws.json:" + jsonFile,
+			check: func(c config) bool {
+				return c.Listen == ":7000" && c.Storage.Backend == "memory" && c.Timeouts.Read == 30*time.Second && c.LogLevel == "warn"
+			},
+		},
+		{
+			name: "environment over file",
+			file: "mws.yaml:" + yamlFile,
+			env:  map[string]string{"MWS_LISTEN": ":7100", "MWS_READ_TIMEOUT": "5s"},
+			check: func(c config) bool {
+				return c.Listen == ":7100" && c.Timeouts.Read == 5*time.Second && c.LogLevel == "warn"
+			},
+		},
+		{
+			name: "explicit flag over environment",
+			file: "mws.yaml:" + yamlFile,
+			env:  map[string]string{"MWS_LISTEN": ":7100", "MWS_LOG_LEVEL": "error"},
+			args: []string{"-listen", ":7200"},
+			check: func(c config) bool {
+				// флаг, который не задан, не перетирает окружение своим значением по умолчанию
+				return c.Listen == ":7200" && c.LogLevel == "error"
+			},
+		},
+		{
+			name: "file from MWS_CONFIG",
+			env:  map[string]string{"MWS_CONFIG": "mws.yaml:" + yamlFile},
+			check: func(c config) bool {
+				return c.Listen == ":7000"
+			},
+		},
+		{
+			name: "deprecated -covers",
+			args: []string{"-covers", "/var/lib/mws/covers"},
+			check: func(c config) bool {
+				return c.Storage.Path == "/var/lib/mws/covers"
+			},
+		},
+		{
+			name: "-storage-path",
+			env:  map[string]string{"MWS_STORAGE_PATH": "/srv/covers"},
+			check: func(c config) bool {
+				return c.Storage.Path == "/srv/covers"
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			args := tt.args
+			if tt.file != "" {
+				name, content, _ := strings.Cut(tt.file, ":")
+				args = append([]string{"-config", writeConfig(t, name, content)}, args...)
+			}
+			for name, value := range tt.env {
+				if name == "MWS_CONFIG" {
+					file, content, _ := strings.Cut(value, ":")
+					value = writeConfig(t, file, content)
+				}
+				t.Setenv(name, value)
+			}
+
+			cfg, printConfig, e := loadConfig(args)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if printConfig {
+				t.Error("print-config is set")
+			}
+			if !tt.check(cfg) {
+				t.Errorf("config = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+		want []string
+	}{
+		{
+			name: "unknown backend",
+			args: []string{"-storage", "s3"},
+			want: []string{`storage.backend: unknown backend "s3"`},
+		},
+		{
+			name: "fs without path",
+			args: []string{"-storage", "fs", "-storage-path", ""},
+			want: []string{"storage.path: required for fs storage"},
+		},
+		{
+			name: "all errors at once",
+			file: "listen: nowhere\npath_prefix: api/\ntimeouts:\n  idle: -1s\nlog_level: loud\n",
+			want: []string{"listen:", `path_prefix: "api/"`, "timeouts.idle: -1s is negative", "log_level:"},
+		},
+		{
+			name: "unknown key in file",
+			file: "listen: \":8080\"\nstorage_path: covers\n",
+			want: []string{"storage_path"},
+		},
+		{
+			name: "invalid duration in environment",
+			env:  map[string]string{"MWS_WRITE_TIMEOUT": "soon"},
+			want: []string{`MWS_WRITE_TIMEOUT: invalid duration "soon"`},
+		},
+		{
+			name: "invalid duration in flag",
+			args: []string{"-idle-timeout", "10"},
+			want: []string{`-idle-timeout: invalid duration "10"`},
+		},
+		{
+			name: "missing catalog",
+			args: []string{"-catalog", "testdata/no-such-catalog.json"},
+			want: []string{"catalog:"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeConfig(t, "mws.yaml", tt.file)}, args...)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, _, e := loadConfig(args)
+			if e == nil {
+				t.Fatal("no error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(e.Error(), want) {
+					t.Errorf("error %q does not mention %q", e, want)
+				}
+			}
+		})
+	}
+}
+
+// вывод -print-config читается обратно как файл настроек и дает те же настройки
+func TestPrintConfig(t *testing.T) {
+	clearEnv(t)
+	cfg, printConfig, e := loadConfig([]string{"-print-config", "-listen", "127.0.0.1:9090", "-path-prefix", "/api", "-storage", "memory", "-read-timeout", "90s"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !printConfig {
+		t.Error("print-config is not set")
+	}
+
+	var out bytes.Buffer
+	if e := cfg.print(&out); e != nil {
+		t.Fatal(e)
+	}
+	printed, _, e := loadConfig([]string{"-config", writeConfig(t, "printed.yaml", out.String())})
+	if e != nil {
+		t.Fatalf("%v\n%s", e, out.String())
+	}
+	if !reflect.DeepEqual(printed, cfg) {
+		t.Errorf("printed config %+v, want %+v\n%s", printed, cfg, out.String())
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	api "mws/gen_api"
+)
+
+const (
+	maxCoverSize = 10 << 20
+	// ограничение на число пикселей, иначе маленький файл распаковался бы в гигабайты. Распакованная картинка
+	// занимает до 8 байт на пиксель (16-битный PNG с прозрачностью), то есть не больше 320 МБ
+	maxCoverPixels = 40_000_000
+	// сколько картинок распаковывается одновременно, так что все вместе они занимают не больше 640 МБ
+	maxCoverDecoders = 2
+	// длинная сторона миниатюры
+	thumbnailSide = 300
+
+	coverCacheControl = "private, max-age=3600"
+)
+
+// blobStore хранит файлы под именем, равным sha256 от их содержимого,
+// так что одинаковые файлы хранятся один раз
+type blobStore interface {
+	// put сохраняет файл и возвращает его хеш
+	put(data []byte) (string, error)
+	open(hash string) (io.ReadCloser, error)
+}
+
+// newBlobStore создает хранилище, выбранное в настройках
+func newBlobStore(cfg storageConfig) (blobStore, error) {
+	if cfg.Backend == "memory" {
+		return &memoryBlobs{blobs: make(map[string][]byte)}, nil
+	}
+	return newDirBlobs(cfg.Path)
+}
+
+func blobHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// dirBlobs хранит файлы в каталоге
+type dirBlobs struct {
+	dir string
+}
+
+func newDirBlobs(dir string) (*dirBlobs, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &dirBlobs{dir: dir}, nil
+}
+
+// файлы раскладываются по подкаталогам по первым двум символам хеша, чтобы не держать все в одном каталоге
+func (b *dirBlobs) path(hash string) string {
+	return filepath.Join(b.dir, hash[:2], hash)
+}
+
+func (b *dirBlobs) put(data []byte) (string, error) {
+	hash := blobHash(data)
+	path := b.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	// пишем во временный файл и переименовываем, чтобы читатели не увидели недописанный файл
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return hash, os.Rename(tmp.Name(), path)
+}
+
+func (b *dirBlobs) open(hash string) (io.ReadCloser, error) {
+	return os.Open(b.path(hash))
+}
+
+// memoryBlobs хранит файлы в памяти, они пропадают при перезапуске, как и остальные данные сервиса
+type memoryBlobs struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func (b *memoryBlobs) put(data []byte) (string, error) {
+	hash := blobHash(data)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.blobs[hash]; !ok {
+		b.blobs[hash] = bytes.Clone(data)
+	}
+	return hash, nil
+}
+
+func (b *memoryBlobs) open(hash string) (io.ReadCloser, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data, ok := b.blobs[hash]
+	if !ok {
+		return nil, fmt.Errorf("blob %s: %w", hash, os.ErrNotExist)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// coverDecoders - семафор на распаковку картинок, параллельные загрузки обложек ждут в нем своей очереди
+var coverDecoders = make(chan struct{}, maxCoverDecoders)
+
+// cover - обложка книги на полке пользователя, сами картинки лежат в blobStore
+type cover struct {
+	hash        string
+	contentType string
+	size        int
+	width       int
+	height      int
+
+	thumbnail string
+}
+
+// coverETag - значение заголовка ETag для картинки с хешем hash, в кавычках, как требует HTTP.
+// Оно же отдается в поле etag обложки, чтобы клиент мог прислать его в If-None-Match без изменений
+func coverETag(hash string) string {
+	return `"` + hash + `"`
+}
+
+func (c *cover) info() *api.Cover {
+	return &api.Cover{
+		Etag:        coverETag(c.hash),
+		ContentType: c.contentType,
+		Size:        c.size,
+		Width:       c.width,
+		Height:      c.height,
+	}
+}
+
+// thumbnail уменьшает картинку так, чтобы длинная сторона была не больше side.
+// Каждый пиксель миниатюры - среднее по покрываемому им прямоугольнику исходной картинки
+func thumbnail(src image.Image, side int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= side && h <= side {
+		return src
+	}
+	tw, th := side, max(1, h*side/w)
+	if h > w {
+		tw, th = max(1, w*side/h), side
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := range th {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := range tw {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// coverThumbnail распаковывает картинку и возвращает ее миниатюру в том же формате.
+// Распаковка занимает место в coverDecoders, пока миниатюра не готова
+func coverThumbnail(ctx context.Context, data []byte, format string) ([]byte, error) {
+	select {
+	case coverDecoders <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-coverDecoders }()
+
+	img, _, e := image.Decode(bytes.NewReader(data))
+	if e != nil {
+		return nil, invalid("broken image: %s", e)
+	}
+	var thumb bytes.Buffer
+	if format == "png" {
+		e = png.Encode(&thumb, thumbnail(img, thumbnailSide))
+	} else {
+		e = jpeg.Encode(&thumb, thumbnail(img, thumbnailSide), &jpeg.Options{Quality: 85})
+	}
+	if e != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", e)
+	}
+	return thumb.Bytes(), nil
+}
+
+// processCover проверяет присланную картинку и готовит обложку с миниатюрой.
+// format - формат, заявленный в Content-Type: jpeg или png
+func (s *serviceImpl) processCover(ctx context.Context, data []byte, format string) (*cover, error) {
+	config, actual, e := image.DecodeConfig(bytes.NewReader(data))
+	if e != nil {
+		return nil, invalid("broken image: %s", e)
+	}
+	if actual != format {
+		return nil, invalid("image is %s, but Content-Type says %s", actual, format)
+	}
+	if config.Width*config.Height > maxCoverPixels {
+		return nil, invalid("image is %dx%d, maximum is %d megapixels", config.Width, config.Height, maxCoverPixels/1_000_000)
+	}
+	thumb, e := coverThumbnail(ctx, data, format)
+	if e != nil {
+		return nil, e
+	}
+
+	c := &cover{
+		contentType: "image/" + format,
+		size:        len(data),
+		width:       config.Width,
+		height:      config.Height,
+	}
+	if c.hash, e = s.blobs.put(data); e != nil {
+		return nil, fmt.Errorf("store cover: %w", e)
+	}
+	if c.thumbnail, e = s.blobs.put(thumb); e != nil {
+		return nil, fmt.Errorf("store thumbnail: %w", e)
+	}
+	return c, nil
+}
+
+// checkBook возвращает ошибку 404, если у пользователя нет такой книги. Вызывается под s.mu
+func (s *serviceImpl) checkBook(userID, bookID int) *problem {
+	if books, ok := s.users[userID]; !ok {
+		return err(api.ErrorCodeUserNotFound, "user %d not found", userID)
+	} else if _, ok := books[bookID]; !ok {
+		return err(api.ErrorCodeBookNotFound, "book %d not found for user %d", bookID, userID)
+	}
+	return nil
+}
+
+func (s *serviceImpl) PutBookCover(ctx context.Context, req api.PutBookCoverReq, params api.PutBookCoverParams) (*api.Cover, error) {
+	// проверяем книгу заранее, чтобы не обрабатывать картинку зря
+	s.mu.RLock()
+	notFound := s.checkBook(params.UserID, params.BookID)
+	s.mu.RUnlock()
+	if notFound != nil {
+		return nil, notFound
+	}
+
+	var body io.Reader
+	var format string
+	switch req := req.(type) {
+	case *api.PutBookCoverReqImageJpeg:
+		body, format = req, "jpeg"
+	case *api.PutBookCoverReqImagePNG:
+		body, format = req, "png"
+	}
+	data, e := io.ReadAll(io.LimitReader(body, maxCoverSize+1))
+	if e != nil {
+		return nil, fmt.Errorf("read cover: %w", e)
+	}
+	if len(data) > maxCoverSize {
+		return nil, err(api.ErrorCodeImageTooLarge, "image is larger than %d MiB", maxCoverSize>>20)
+	}
+
+	c, e := s.processCover(ctx, data, format)
+	if e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// книгу могли удалить, пока обрабатывалась картинка
+	if notFound := s.checkBook(params.UserID, params.BookID); notFound != nil {
+		return nil, notFound
+	}
+	if _, ok := s.covers[params.UserID]; !ok {
+		s.covers[params.UserID] = make(map[int]cover)
+	}
+	s.covers[params.UserID][params.BookID] = *c
+	return c.info(), nil
+}
+
+// etagMatches проверяет заголовок If-None-Match, в котором может быть несколько ETag через запятую или *
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *serviceImpl) GetBookCover(ctx context.Context, params api.GetBookCoverParams) (api.GetBookCoverRes, error) {
+	s.mu.RLock()
+	notFound := s.checkBook(params.UserID, params.BookID)
+	c, ok := s.covers[params.UserID][params.BookID]
+	s.mu.RUnlock()
+	if notFound != nil {
+		return nil, notFound
+	} else if !ok {
+		return nil, err(api.ErrorCodeCoverNotFound, "book %d of user %d has no cover", params.BookID, params.UserID)
+	}
+
+	hash := c.hash
+	if params.Size.Or(api.GetBookCoverSizeOriginal) == api.GetBookCoverSizeThumbnail {
+		hash = c.thumbnail
+	}
+	etag := api.NewOptString(coverETag(hash))
+	cacheControl := api.NewOptString(coverCacheControl)
+	if inm, ok := params.IfNoneMatch.Get(); ok && etagMatches(inm, etag.Value) {
+		return &api.GetBookCoverNotModified{CacheControl: cacheControl, Etag: etag}, nil
+	}
+
+	// файл закроет сгенерированный сервер после отправки
+	f, e := s.blobs.open(hash)
+	if e != nil {
+		return nil, fmt.Errorf("open cover: %w", e)
+	}
+	if c.contentType == "image/png" {
+		return &api.GetBookCoverOKImagePNGHeaders{CacheControl: cacheControl, Etag: etag, Response: api.GetBookCoverOKImagePNG{Data: f}}, nil
+	}
+	return &api.GetBookCoverOKImageJpegHeaders{CacheControl: cacheControl, Etag: etag, Response: api.GetBookCoverOKImageJpeg{Data: f}}, nil
+}
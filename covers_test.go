@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"testing"
+
+	api "mws/gen_api"
+)
+
+// pngHeader возвращает начало PNG с заголовком IHDR, этого хватает image.DecodeConfig,
+// так что огромную картинку не нужно создавать целиком
+func pngHeader(width, height int) []byte {
+	var b bytes.Buffer
+	b.WriteString("\x89PNG\r\n\x1a\n")
+	chunk := binary.BigEndian.AppendUint32([]byte("IHDR"), uint32(width))
+	chunk = binary.BigEndian.AppendUint32(chunk, uint32(height))
+	// 8 бит на канал, RGBA, без чересстрочности
+	chunk = append(chunk, 8, 6, 0, 0, 0)
+	b.Write(binary.BigEndian.AppendUint32(nil, uint32(len(chunk)-4)))
+	b.Write(chunk)
+	b.Write(binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(chunk)))
+	return b.Bytes()
+}
+
+func TestProcessCoverPixelLimit(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	for _, tt := range []struct {
+		width, height int
+		tooLarge      bool
+	}{
+		{8000, 5000, false},
+		{8000, 5001, true},
+		// вытянутая картинка проходила по ограничению на сторону, но не по числу пикселей
+		{7999, 7999, true},
+		{40000, 1000, false},
+	} {
+		_, e := s.processCover(context.Background(), pngHeader(tt.width, tt.height), "png")
+		var p *problem
+		if !errors.As(e, &p) || p.code != api.ErrorCodeInvalidRequest {
+			t.Fatalf("%dx%d: error %v, want invalid_request", tt.width, tt.height, e)
+		}
+		// у заголовка нет данных, так что картинка в пределах ограничения ломается уже при распаковке
+		if tooLarge := p.detail.format != "broken image: %s"; tooLarge != tt.tooLarge {
+			t.Errorf("%dx%d: %v, too large = %v", tt.width, tt.height, e, tt.tooLarge)
+		}
+	}
+}
+
+func TestCoverThumbnailWaitsForDecoder(t *testing.T) {
+	var data bytes.Buffer
+	if e := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 600, 400))); e != nil {
+		t.Fatal(e)
+	}
+
+	// все места заняты другими загрузками
+	for range maxCoverDecoders {
+		coverDecoders <- struct{}{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, e := coverThumbnail(ctx, data.Bytes(), "png")
+	for range maxCoverDecoders {
+		<-coverDecoders
+	}
+	if !errors.Is(e, context.Canceled) {
+		t.Fatalf("error = %v, want the upload to wait for a free decoder", e)
+	}
+
+	thumb, e := coverThumbnail(context.Background(), data.Bytes(), "png")
+	if e != nil {
+		t.Fatal(e)
+	}
+	config, e := png.DecodeConfig(bytes.NewReader(thumb))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if config.Width != thumbnailSide || config.Height != 200 {
+		t.Errorf("thumbnail is %dx%d, want %dx200", config.Width, config.Height, thumbnailSide)
+	}
+}
+
+func TestCoverETagRoundTrip(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	ctx := context.Background()
+	if _, e := s.AddUserBook(ctx, &api.NewBook{ID: 1, Title: api.NewOptString("Dracula"), Author: api.NewOptString("Bram Stoker")},
+		api.AddUserBookParams{UserID: 1}); e != nil {
+		t.Fatal(e)
+	}
+	var data bytes.Buffer
+	if e := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 20, 30))); e != nil {
+		t.Fatal(e)
+	}
+	info, e := s.PutBookCover(ctx, &api.PutBookCoverReqImagePNG{Data: &data}, api.PutBookCoverParams{UserID: 1, BookID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	res, e := s.GetBookCover(ctx, api.GetBookCoverParams{UserID: 1, BookID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if etag := res.(*api.GetBookCoverOKImagePNGHeaders).Etag.Value; etag != info.Etag {
+		t.Errorf("ETag header %s, etag field %s, want the same value", etag, info.Etag)
+	}
+
+	// etag из ответа на загрузку годится для If-None-Match как есть
+	res, e = s.GetBookCover(ctx, api.GetBookCoverParams{UserID: 1, BookID: 1, IfNoneMatch: api.NewOptString(info.Etag)})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, ok := res.(*api.GetBookCoverNotModified); !ok {
+		t.Errorf("response %T, want 304", res)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	api "mws/gen_api"
+)
+
+// findEdition возвращает издание книги по id или nil
+func findEdition(book *api.Book, id int) *api.Edition {
+	if i := slices.IndexFunc(book.Editions, func(e api.Edition) bool { return e.ID == id }); i >= 0 {
+		return &book.Editions[i]
+	}
+	return nil
+}
+
+// checkEditions проверяет издания присланной книги и берет число страниц из читаемого издания, если оно бумажное
+func checkEditions(book *api.Book) error {
+	seen := make(map[int]bool, len(book.Editions))
+	for i := range book.Editions {
+		edition := &book.Editions[i]
+		if edition.ID < 1 || seen[edition.ID] {
+			return invalidField("editions", "edition id %d is not positive or not unique", edition.ID)
+		}
+		seen[edition.ID] = true
+		if length, ok := edition.Length.Get(); ok && length < 1 {
+			return invalidField("editions", "edition %d has length %d", edition.ID, length)
+		}
+		if isbn, ok := edition.Isbn13.Get(); ok {
+			_, isbn13, e := resolveISBN("", isbn)
+			if e != nil {
+				return invalidField("editions", "edition %d: %s", edition.ID, e)
+			}
+			edition.Isbn13 = api.NewOptString(isbn13)
+		}
+	}
+
+	if id, ok := book.Edition.Get(); ok {
+		edition := findEdition(book, id)
+		if edition == nil {
+			return invalidField("edition", "book has no edition %d", id)
+		}
+		setEditionPages(book, edition)
+	}
+	return nil
+}
+
+// setEditionPages берет число страниц книги из издания, которое меряется в страницах
+func setEditionPages(book *api.Book, edition *api.Edition) {
+	if length, ok := edition.Length.Get(); ok && editionUnit(edition) == api.ProgressUnitPage {
+		book.Pages = api.NewOptInt(length)
+	}
+}
+
+func (s *serviceImpl) AddBookEdition(ctx context.Context, req *api.NewEdition, params api.AddBookEditionParams) (*api.Edition, error) {
+	edition := api.Edition{Format: req.Format, Unit: req.Unit, Length: req.Length}
+	if isbn, ok := req.Isbn13.Get(); ok {
+		_, isbn13, e := resolveISBN("", isbn)
+		if e != nil {
+			return nil, invalidField("isbn13", "%s", e)
+		}
+		edition.Isbn13 = api.NewOptString(isbn13)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	book := s.users[params.UserID][params.BookID]
+	for _, other := range book.Editions {
+		edition.ID = max(edition.ID, other.ID)
+	}
+	edition.ID++
+	book.Editions = append(slices.Clone(book.Editions), edition)
+	s.users[params.UserID][params.BookID] = book
+	return &edition, nil
+}
+
+func (s *serviceImpl) SwitchBookEdition(ctx context.Context, req *api.EditionSwitch, params api.SwitchBookEditionParams) (*api.Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	book := s.users[params.UserID][params.BookID]
+	edition := findEdition(&book, req.Edition)
+	if edition == nil {
+		return nil, err(api.ErrorCodeEditionNotFound, "book %d has no edition %d", params.BookID, req.Edition)
+	}
+
+	// с начала книги можно перейти на любое издание, иначе нужна длина обоих изданий
+	unit, _, _ := bookUnit(&book)
+	newUnit := editionUnit(edition)
+	position := firstPosition(newUnit)
+	if book.Page > firstPosition(unit) {
+		read, fromOk := progress(&book)
+		to, toOk := editionLength(edition)
+		if !fromOk || !toOk {
+			return nil, err(api.ErrorCodeLengthUnknown, "length of the current or the new edition is unknown, progress can't be carried over")
+		}
+		position = fromFraction(read, to, newUnit)
+	}
+
+	book.Edition = api.NewOptInt(edition.ID)
+	setEditionPages(&book, edition)
+	setPage(&book, position)
+	s.users[params.UserID][params.BookID] = book
+	// позиция на начало дня была в единицах прежнего издания
+	if plan, ok := s.plans[params.UserID][params.BookID]; ok {
+		plan.startDay = ""
+	}
+
+	book = s.present(params.UserID, book)
+	return &book, nil
+}
+
+func (s *serviceImpl) GetUserSeries(ctx context.Context, params api.GetUserSeriesParams) ([]api.SeriesBooks, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// название серии сравнивается без учета регистра, выводится как у первой книги серии
+	bySeries := make(map[string]*api.SeriesBooks)
+	for _, book := range s.users[params.UserID] {
+		series, ok := book.Series.Get()
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(series.Name))
+		if _, ok := bySeries[key]; !ok {
+			bySeries[key] = &api.SeriesBooks{Name: series.Name}
+		}
+		bySeries[key].Books = append(bySeries[key].Books, s.present(params.UserID, book))
+	}
+
+	result := make([]api.SeriesBooks, 0, len(bySeries))
+	for _, series := range bySeries {
+		// книги без номера идут в конце
+		slices.SortFunc(series.Books, func(a, b api.Book) int {
+			return cmp.Or(
+				cmp.Compare(a.Series.Value.Number.Or(math.Inf(1)), b.Series.Value.Number.Or(math.Inf(1))),
+				cmp.Compare(a.ID, b.ID),
+			)
+		})
+		series.Name = series.Books[0].Series.Value.Name
+		result = append(result, *series)
+	}
+	slices.SortFunc(result, func(a, b api.SeriesBooks) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return result, nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	api "mws/gen_api"
+)
+
+const (
+	// ограничение на размер загружаемого EPUB
+	maxEpubSize = 64 << 20
+	// примерное число символов текста на странице бумажной книги
+	charsPerPage = 1800
+)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// opfPackage - нужная нам часть OPF документа. Пространства имен (dc:, opf:) не указываются,
+// encoding/xml сопоставляет элементы по локальному имени
+type opfPackage struct {
+	Metadata struct {
+		Titles   []string `xml:"title"`
+		Creators []struct {
+			ID   string `xml:"id,attr"`
+			Role string `xml:"role,attr"`
+			Name string `xml:",chardata"`
+		} `xml:"creator"`
+		Dates       []string `xml:"date"`
+		Languages   []string `xml:"language"`
+		Identifiers []string `xml:"identifier"`
+		// в EPUB 3 роль автора задается отдельно: <meta refines="#creator1" property="role">aut</meta>
+		Metas []struct {
+			Refines  string `xml:"refines,attr"`
+			Property string `xml:"property,attr"`
+			Value    string `xml:",chardata"`
+		} `xml:"meta"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID   string `xml:"id,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+func readZipXML(archive *zip.Reader, name string, v any) error {
+	f, e := archive.Open(name)
+	if e != nil {
+		return e
+	}
+	defer f.Close()
+	return xml.NewDecoder(f).Decode(v)
+}
+
+// textLength считает число символов текста в XHTML документе, идущие подряд пробелы считаются за один
+func textLength(r io.Reader) (int, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	length := 0
+	for {
+		token, e := d.Token()
+		if errors.Is(e, io.EOF) {
+			return length, nil
+		} else if e != nil {
+			return 0, e
+		}
+		if text, ok := token.(xml.CharData); ok {
+			for _, word := range strings.Fields(string(text)) {
+				length += utf8.RuneCountInString(word) + 1
+			}
+		}
+	}
+}
+
+// parseEpubDate разбирает дату публикации, которая в OPF может быть указана с точностью до года
+func parseEpubDate(date string) (time.Time, bool) {
+	date = strings.TrimSpace(date)
+	for _, layout := range []string{time.DateOnly, "2006-01", "2006"} {
+		if len(date) >= len(layout) {
+			if t, e := time.Parse(layout, date[:len(layout)]); e == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseEpub достает из EPUB сведения о книге для добавления на полку
+func parseEpub(r io.ReaderAt, size int64) (*api.NewBook, error) {
+	archive, e := zip.NewReader(r, size)
+	if e != nil {
+		return nil, invalid("not an EPUB file: %s", e)
+	}
+
+	var container epubContainer
+	if e := readZipXML(archive, "META-INF/container.xml", &container); e != nil {
+		return nil, invalid("read EPUB container: %s", e)
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, invalid("EPUB container has no package document")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+
+	var opf opfPackage
+	if e := readZipXML(archive, opfPath, &opf); e != nil {
+		return nil, invalid("read EPUB package document: %s", e)
+	}
+	meta := &opf.Metadata
+
+	book := &api.NewBook{}
+	if len(meta.Titles) > 0 {
+		book.Title = api.NewOptString(strings.TrimSpace(meta.Titles[0]))
+	}
+
+	roles := make(map[string]string)
+	for _, m := range meta.Metas {
+		if m.Property == "role" {
+			roles[strings.TrimPrefix(m.Refines, "#")] = strings.TrimSpace(m.Value)
+		}
+	}
+	var authors []string
+	for _, c := range meta.Creators {
+		role := c.Role
+		if role == "" {
+			role = roles[c.ID]
+		}
+		// создатели без роли считаются авторами, иллюстраторы и т.п. пропускаются
+		name := strings.TrimSpace(c.Name)
+		switch role {
+		case "", "aut":
+			authors = append(authors, name)
+			book.Contributors = append(book.Contributors, api.NewContributor{Name: name, Role: api.NewOptContributorRole(api.ContributorRoleAuthor)})
+		case "trl":
+			book.Contributors = append(book.Contributors, api.NewContributor{Name: name, Role: api.NewOptContributorRole(api.ContributorRoleTranslator)})
+		case "edt":
+			book.Contributors = append(book.Contributors, api.NewContributor{Name: name, Role: api.NewOptContributorRole(api.ContributorRoleEditor)})
+		}
+	}
+	if len(authors) > 0 {
+		book.Author = api.NewOptString(strings.Join(authors, ", "))
+	}
+
+	for _, date := range meta.Dates {
+		if published, ok := parseEpubDate(date); ok {
+			book.Published = api.NewOptDate(published)
+			break
+		}
+	}
+	if len(meta.Languages) > 0 {
+		book.Language = api.NewOptString(strings.TrimSpace(meta.Languages[0]))
+	}
+
+	// ISBN записывают как urn:isbn:978... или просто числом, в том числе с дефисами.
+	// Берется первый, у электронного и бумажного изданий ISBN разные
+	for _, id := range meta.Identifiers {
+		isbn := normalizeISBN(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(id)), "urn:isbn:"))
+		if validISBN13(isbn) {
+			book.Isbn13 = api.NewOptString(isbn)
+			break
+		} else if validISBN10(isbn) {
+			book.Isbn10 = api.NewOptString(isbn)
+			break
+		}
+	}
+
+	// пути в манифесте указываются относительно OPF документа
+	hrefs := make(map[string]string)
+	for _, item := range opf.Manifest {
+		hrefs[item.ID] = path.Join(path.Dir(opfPath), item.Href)
+	}
+	length := 0
+	for _, ref := range opf.Spine {
+		f, e := archive.Open(hrefs[ref.IDRef])
+		if e != nil {
+			return nil, invalid("read EPUB content %q: %s", ref.IDRef, e)
+		}
+		n, e := textLength(f)
+		f.Close()
+		if e != nil {
+			return nil, invalid("read EPUB content %q: %s", ref.IDRef, e)
+		}
+		length += n
+	}
+	if length > 0 {
+		book.Pages = api.NewOptInt((length + charsPerPage - 1) / charsPerPage)
+	}
+	return book, nil
+}
+
+func (s *serviceImpl) AddUserBookEpub(ctx context.Context, req *api.AddUserBookEpubReq, params api.AddUserBookEpubParams) (*api.Book, error) {
+	book, e := s.addEpub(ctx, params.UserID, req)
+	if e != nil {
+		return nil, e
+	}
+	return &book, nil
+}
+
+func (s *serviceImpl) addEpub(ctx context.Context, userID int, req *api.AddUserBookEpubReq) (api.Book, error) {
+	// zip нужен произвольный доступ, так что файл читается в память целиком
+	data, e := io.ReadAll(io.LimitReader(req.File.File, maxEpubSize+1))
+	if e != nil {
+		return api.Book{}, fmt.Errorf("read uploaded file: %w", e)
+	}
+	if len(data) > maxEpubSize {
+		return api.Book{}, invalid("EPUB file is larger than %d MiB", maxEpubSize>>20)
+	}
+
+	book, e := parseEpub(bytes.NewReader(data), int64(len(data)))
+	if e != nil {
+		return api.Book{}, e
+	}
+	if id, ok := req.ID.Get(); ok {
+		book.ID = id
+	} else if book.Title.Set && book.Author.Set {
+		book.ID = derivedBookID(book.Title.Value, book.Author.Value)
+	} else {
+		return api.Book{}, invalidField("id", "EPUB has no title or author, book id is required")
+	}
+	return s.addBook(ctx, userID, book)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	ht "github.com/ogen-go/ogen/http"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/validate"
+	"golang.org/x/text/language"
+
+	api "mws/gen_api"
+)
+
+// Ошибки отдаются по RFC 7807. Клиент различает их по code, type - тот же код в виде URI,
+// title одинаков для всех ошибок с этим кодом, а подробности конкретного случая лежат в detail.
+// Тексты переводятся на язык запроса, когда ошибка отдается клиенту, переводы лежат в messages.go
+
+// problemType - префикс type, к нему дописывается код ошибки
+const problemType = "urn:mws:error:"
+
+var statuses = map[api.ErrorCode]int{
+	api.ErrorCodeInvalidRequest:  http.StatusBadRequest,
+	api.ErrorCodeUnknownTimeZone: http.StatusBadRequest,
+	api.ErrorCodeInvalidCursor:   http.StatusBadRequest,
+	api.ErrorCodeInvalidRange:    http.StatusBadRequest,
+	api.ErrorCodeEmptyQuery:      http.StatusBadRequest,
+	api.ErrorCodeSelfFollow:      http.StatusBadRequest,
+
+	api.ErrorCodeUserNotFound:      http.StatusNotFound,
+	api.ErrorCodeBookNotFound:      http.StatusNotFound,
+	api.ErrorCodeNoteNotFound:      http.StatusNotFound,
+	api.ErrorCodeHighlightNotFound: http.StatusNotFound,
+	api.ErrorCodeBookmarkNotFound:  http.StatusNotFound,
+	api.ErrorCodeAuthorNotFound:    http.StatusNotFound,
+	api.ErrorCodeClubNotFound:      http.StatusNotFound,
+	api.ErrorCodeMemberNotFound:    http.StatusNotFound,
+	api.ErrorCodeShelfNotFound:     http.StatusNotFound,
+	api.ErrorCodeBookNotOnShelf:    http.StatusNotFound,
+	api.ErrorCodeCoverNotFound:     http.StatusNotFound,
+	api.ErrorCodeEditionNotFound:   http.StatusNotFound,
+	api.ErrorCodePlanNotFound:      http.StatusNotFound,
+	api.ErrorCodeReviewNotFound:    http.StatusNotFound,
+	api.ErrorCodeQueueEmpty:        http.StatusNotFound,
+	api.ErrorCodeNotFollowing:      http.StatusNotFound,
+
+	api.ErrorCodeBookExists:       http.StatusConflict,
+	api.ErrorCodeAlreadyReading:   http.StatusConflict,
+	api.ErrorCodeBookNotRead:      http.StatusConflict,
+	api.ErrorCodeBookRead:         http.StatusConflict,
+	api.ErrorCodeNotInQueue:       http.StatusConflict,
+	api.ErrorCodeShelfExists:      http.StatusConflict,
+	api.ErrorCodeLengthUnknown:    http.StatusConflict,
+	api.ErrorCodeAlreadyMember:    http.StatusConflict,
+	api.ErrorCodeAlreadyInvited:   http.StatusConflict,
+	api.ErrorCodeNotInvited:       http.StatusConflict,
+	api.ErrorCodeOwnerCannotLeave: http.StatusConflict,
+	api.ErrorCodeNoCurrentBook:    http.StatusConflict,
+
+	api.ErrorCodeImageTooLarge:        http.StatusRequestEntityTooLarge,
+	api.ErrorCodeNotFound:             http.StatusNotFound,
+	api.ErrorCodeMethodNotAllowed:     http.StatusMethodNotAllowed,
+	api.ErrorCodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	api.ErrorCodeNotImplemented:       http.StatusNotImplemented,
+	api.ErrorCodeInternal:             http.StatusInternalServerError,
+}
+
+// text - текст для клиента до перевода: английский формат и аргументы к нему. Аргументы-тексты
+// переводятся вместе с ним
+type text struct {
+	format string
+	args   []any
+}
+
+// in собирает текст на языке lang, переводы ищутся среди текстов ошибок с кодом code
+func (t text) in(lang language.Tag, code api.ErrorCode) string {
+	args := make([]any, len(t.args))
+	for i, arg := range t.args {
+		if nested, ok := arg.(text); ok {
+			arg = nested.in(lang, code)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(translate(lang, code, t.format), args...)
+}
+
+type fieldProblem struct {
+	field   string
+	message text
+}
+
+// problem - ошибка, которую видит клиент. Хендлеры возвращают ее как обычную ошибку,
+// ответ из нее собирается уже на языке запроса
+type problem struct {
+	code   api.ErrorCode
+	detail text
+	fields []fieldProblem
+}
+
+// Error возвращает detail по-английски, так ошибка попадает в лог и в отчет об импорте
+func (p *problem) Error() string {
+	return p.detail.in(language.English, p.code)
+}
+
+// response собирает ответ на языке lang
+func (p *problem) response(lang language.Tag) *api.ErrorStatusCode {
+	status := statuses[p.code]
+	res := &api.ErrorStatusCode{
+		StatusCode: status,
+		Response: api.Error{
+			Type:   problemType + string(p.code),
+			Title:  title(lang, p.code),
+			Status: status,
+			Detail: api.NewOptString(p.detail.in(lang, p.code)),
+			Code:   p.code,
+		},
+	}
+	for _, f := range p.fields {
+		res.Response.Errors = append(res.Response.Errors, api.FieldError{Field: f.field, Message: f.message.in(lang, p.code)})
+	}
+	return res
+}
+
+// err собирает ошибку с кодом code, detail формируется по format. Формат должен быть константой:
+// по нему ищется перевод
+func err(code api.ErrorCode, format string, args ...any) *problem {
+	return &problem{code: code, detail: text{format, args}}
+}
+
+// invalid - ошибка в присланных данных
+func invalid(format string, args ...any) *problem {
+	return err(api.ErrorCodeInvalidRequest, format, args...)
+}
+
+// invalidField - ошибка в поле field присланных данных
+func invalidField(field, format string, args ...any) *problem {
+	e := invalid(format, args...)
+	e.fields = []fieldProblem{{field, e.detail}}
+	return e
+}
+
+// internal - ошибка, которая не была собрана через err. Такая ошибка - сбой самого сервиса,
+// ее подробности пишутся в лог, а не клиенту
+func internal(e error) *problem {
+	var p *problem
+	if errors.As(e, &p) {
+		return p
+	}
+	slog.Error("internal error", "error", e)
+	return err(api.ErrorCodeInternal, "request can't be completed, try again later")
+}
+
+// NewError превращает ошибку в ответ. Ошибки хендлеров сюда приходят уже переведенными из localizeErrors
+func (s *serviceImpl) NewError(ctx context.Context, e error) *api.ErrorStatusCode {
+	var res *api.ErrorStatusCode
+	if errors.As(e, &res) {
+		return res
+	}
+	return internal(e).response(defaultLanguage)
+}
+
+// localizeErrors переводит ошибку хендлера на язык запроса и дописывает в нее путь, на который запрос получен
+func (s *serviceImpl) localizeErrors(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+	resp, e := next(req)
+	if e == nil || errors.Is(e, ht.ErrNotImplemented) {
+		return resp, e
+	}
+	fallback := defaultLanguage
+	if userID, ok := req.Params.Path("user_id"); ok {
+		fallback = s.userLanguage(userID.(int))
+	}
+	res := internal(e).response(requestLanguage(req.Raw, fallback))
+	res.Response.Instance = api.NewOptString(req.Raw.URL.Path)
+	return resp, res
+}
+
+// Ошибки, которые возникают до вызова хендлера: запрос не разобрался, не прошел проверки из api.yml
+// или пришел на неизвестный путь. Сгенерированный сервер отдает их сам, здесь они приводятся к тому же виду.
+// Пользователь здесь еще неизвестен, поэтому язык берется только из Accept-Language
+
+// writeProblem отдает ошибку, собранную вне хендлера
+func writeProblem(w http.ResponseWriter, r *http.Request, p *problem) {
+	res := p.response(requestLanguage(r, defaultLanguage))
+	res.Response.Instance = api.NewOptString(r.URL.Path)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(res.StatusCode)
+	if e := json.NewEncoder(w).Encode(&res.Response); e != nil {
+		slog.Error("write error response", "error", e)
+	}
+}
+
+// requestProblem превращает ошибку разбора запроса в ответ с подходящим кодом
+func requestProblem(e error) *problem {
+	var (
+		contentType *validate.InvalidContentTypeError
+		param       *ogenerrors.DecodeParamError
+		fields      *validate.Error
+		body        *ogenerrors.DecodeBodyError
+		decode      ogenerrors.Error
+	)
+	switch {
+	case errors.Is(e, ht.ErrNotImplemented):
+		return err(api.ErrorCodeNotImplemented, "operation is not implemented yet")
+	case errors.As(e, &contentType):
+		return err(api.ErrorCodeUnsupportedMediaType, "Content-Type %q is not accepted by the operation", contentType.ContentType)
+	case errors.As(e, &param):
+		p := invalid("%s parameter %q is invalid: %s", param.In, param.Name, param.Err)
+		p.fields = []fieldProblem{{param.Name, text{"%s", []any{param.Err}}}}
+		return p
+	case errors.As(e, &fields):
+		if p, ok := schemaChecks("", fields).problem().(*problem); ok {
+			return p
+		}
+		return invalid("request body is invalid")
+	case errors.As(e, &body):
+		return invalid("request body is not valid %s: %s", body.ContentType, body.Err)
+	case errors.Is(e, validate.ErrBodyRequired):
+		return invalid("request body is required")
+	case errors.As(e, &decode) && decode.Code() == http.StatusBadRequest:
+		return invalid("%s", decode.Unwrap())
+	}
+	return internal(e)
+}
+
+// handleError - ErrorHandler сгенерированного сервера
+func handleError(ctx context.Context, w http.ResponseWriter, r *http.Request, e error) {
+	writeProblem(w, r, requestProblem(e))
+}
+
+func handleNotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, err(api.ErrorCodeNotFound, "no route for %s", r.URL.Path))
+}
+
+func handleMethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed string) {
+	// на preflight запрос браузера отвечаем так же, как сгенерированный сервер по умолчанию
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", allowed)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Allow", allowed)
+	writeProblem(w, r, err(api.ErrorCodeMethodNotAllowed, "%s is not allowed for %s, allowed: %s", r.Method, r.URL.Path, allowed))
+}
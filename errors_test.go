@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/yaml"
+
+	api "mws/gen_api"
+)
+
+// operation - то, что тесту нужно знать об операции из api.yml
+type operation struct {
+	method       string
+	path         string
+	contentTypes []string
+}
+
+// operations читает все операции из api.yml, чтобы новая операция попадала в тест без правки таблицы
+func operations(t *testing.T) []operation {
+	t.Helper()
+	type spec struct {
+		RequestBody *struct {
+			Content map[string]any `yaml:"content"`
+		} `yaml:"requestBody"`
+	}
+	var doc struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	data, e := os.ReadFile("api.yml")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e := yaml.Unmarshal(data, &doc); e != nil {
+		t.Fatal(e)
+	}
+
+	var ops []operation
+	for path, methods := range doc.Paths {
+		for method, node := range methods {
+			method = strings.ToUpper(method)
+			if !slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}, method) {
+				continue
+			}
+			var s spec
+			if e := node.Decode(&s); e != nil {
+				t.Fatalf("%s %s: %v", method, path, e)
+			}
+			op := operation{method: method, path: path}
+			if s.RequestBody != nil {
+				for contentType := range s.RequestBody.Content {
+					op.contentTypes = append(op.contentTypes, contentType)
+				}
+			}
+			ops = append(ops, op)
+		}
+	}
+	slices.SortFunc(ops, func(a, b operation) int {
+		return strings.Compare(a.path+" "+a.method, b.path+" "+b.method)
+	})
+	return ops
+}
+
+var pathParamRe = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// url подставляет в путь операции 1 вместо всех параметров, кроме param, для него - value
+func (op operation) url(param, value string) string {
+	return pathParamRe.ReplaceAllStringFunc(op.path, func(p string) string {
+		if p == "{"+param+"}" {
+			return value
+		}
+		return "1"
+	})
+}
+
+// idParam - параметр пути, на котором проверяются неверные id: user_id, если он есть, иначе первый
+func (op operation) idParam() string {
+	params := pathParamRe.FindAllStringSubmatch(op.path, -1)
+	for _, p := range params {
+		if p[1] == "user_id" {
+			return p[1]
+		}
+	}
+	if len(params) > 0 {
+		return params[0][1]
+	}
+	return ""
+}
+
+func TestRequestErrors(t *testing.T) {
+	service := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	server, e := api.NewServer(service,
+		api.WithErrorHandler(handleError),
+		api.WithNotFound(handleNotFound),
+		api.WithMethodNotAllowed(handleMethodNotAllowed),
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	type request struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		status      int
+		code        api.ErrorCode
+	}
+	ops := operations(t)
+	if len(ops) == 0 {
+		t.Fatal("no operations in api.yml")
+	}
+	var requests []request
+	for _, op := range ops {
+		name := op.method + " " + op.path
+		// тело нужно, чтобы до проверки параметров не дошла ошибка пустого тела
+		contentType, body := "", ""
+		if len(op.contentTypes) > 0 {
+			contentType, body = op.contentTypes[0], "{}"
+			if slices.Contains(op.contentTypes, "application/json") {
+				contentType = "application/json"
+			}
+		}
+		if param := op.idParam(); param != "" {
+			requests = append(requests,
+				request{name + " non-integer " + param, op.method, op.url(param, "abc"), contentType, body, http.StatusBadRequest, api.ErrorCodeInvalidRequest},
+				request{name + " zero " + param, op.method, op.url(param, "0"), contentType, body, http.StatusBadRequest, api.ErrorCodeInvalidRequest},
+			)
+		}
+		if slices.Contains(op.contentTypes, "application/json") {
+			requests = append(requests,
+				request{name + " malformed JSON", op.method, op.url("", ""), "application/json", `{"title": `, http.StatusBadRequest, api.ErrorCodeInvalidRequest})
+		}
+		if len(op.contentTypes) > 0 {
+			requests = append(requests,
+				request{name + " unsupported Content-Type", op.method, op.url("", ""), "application/xml", "<book/>", http.StatusUnsupportedMediaType, api.ErrorCodeUnsupportedMediaType})
+		}
+		// PATCH в api.yml не используется, так что для любого пути это неверный метод
+		requests = append(requests,
+			request{name + " wrong method", http.MethodPatch, op.url("", ""), "", "", http.StatusMethodNotAllowed, api.ErrorCodeMethodNotAllowed})
+	}
+	requests = append(requests,
+		request{"unknown path", http.MethodGet, "/nothing/here", "", "", http.StatusNotFound, api.ErrorCodeNotFound},
+		request{"unknown nested path", http.MethodGet, "/users/1/nothing", "", "", http.StatusNotFound, api.ErrorCodeNotFound},
+	)
+
+	for _, tt := range requests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			var res struct {
+				Code     api.ErrorCode `json:"code"`
+				Instance string        `json:"instance"`
+			}
+			if e := json.Unmarshal(w.Body.Bytes(), &res); e != nil {
+				t.Fatalf("%v: %s", e, w.Body)
+			}
+			if res.Code != tt.code {
+				t.Errorf("code = %s, want %s: %s", res.Code, tt.code, w.Body)
+			}
+			if res.Instance != tt.url {
+				t.Errorf("instance = %q, want %q", res.Instance, tt.url)
+			}
+			if tt.status == http.StatusMethodNotAllowed && w.Header().Get("Allow") == "" {
+				t.Error("no Allow header")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Лента строится при записи: событие сразу раскладывается в ленты всех подписчиков, так что чтение
+// ленты не обходит полки. Видимость проверяется еще и при чтении, чтобы отписка, скрытие активности
+// и смена видимости отзыва убирали из ленты уже разосланные события
+
+// сколько последних событий хранится в ленте пользователя
+const feedLimit = 500
+
+// follows проверяет, подписан ли follower на пользователя. Вызывается под s.mu
+func (s *serviceImpl) follows(follower, userID int) bool {
+	_, ok := s.following[follower][userID]
+	return ok
+}
+
+// publish раздает событие в ленты подписчиков, если пользователь не скрыл свою активность. Вызывается под s.mu
+func (s *serviceImpl) publish(activity api.Activity) {
+	if s.privacy[activity.UserID] == api.ActivityVisibilityNobody {
+		return
+	}
+	s.lastActivityID++
+	activity.ID = s.lastActivityID
+	activity.At = time.Now()
+	for follower := range s.followers[activity.UserID] {
+		feed := append(s.feeds[follower], activity)
+		if len(feed) > feedLimit {
+			feed = slices.Delete(feed, 0, len(feed)-feedLimit)
+		}
+		s.feeds[follower] = feed
+	}
+}
+
+// publishStatus сообщает подписчикам, что пользователь начал или закончил читать книгу. Вызывается под s.mu
+func (s *serviceImpl) publishStatus(userID int, before api.ReadingStatus, book *api.Book) {
+	activity := api.Activity{UserID: userID, BookID: book.ID, Title: book.Title, Author: api.NewOptString(book.Author)}
+	switch {
+	case book.Status == before:
+		return
+	case book.Status == api.ReadingStatusReading:
+		activity.Type = api.ActivityTypeStarted
+	case book.Status == api.ReadingStatusRead:
+		activity.Type = api.ActivityTypeFinished
+	default:
+		return
+	}
+	s.publish(activity)
+}
+
+// activityVisible проверяет, можно ли показать событие в ленте viewer. Вызывается под s.mu
+func (s *serviceImpl) activityVisible(activity *api.Activity, viewer int) bool {
+	if !s.follows(viewer, activity.UserID) || s.privacy[activity.UserID] == api.ActivityVisibilityNobody {
+		return false
+	}
+	if activity.Type == api.ActivityTypeRated {
+		review, ok := s.reviews[activity.BookID][activity.UserID]
+		return ok && s.reviewVisible(&review, viewer)
+	}
+	return true
+}
+
+func (s *serviceImpl) GetFollowing(ctx context.Context, params api.GetFollowingParams) ([]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]int{}, slices.Sorted(maps.Keys(s.following[params.UserID]))...), nil
+}
+
+func (s *serviceImpl) GetFollowers(ctx context.Context, params api.GetFollowersParams) ([]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]int{}, slices.Sorted(maps.Keys(s.followers[params.UserID]))...), nil
+}
+
+func (s *serviceImpl) FollowUser(ctx context.Context, params api.FollowUserParams) error {
+	if params.UserID == params.FolloweeID {
+		return err(api.ErrorCodeSelfFollow, "user %d can't follow themselves", params.UserID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.following[params.UserID]; !ok {
+		s.following[params.UserID] = make(map[int]struct{})
+	}
+	if _, ok := s.followers[params.FolloweeID]; !ok {
+		s.followers[params.FolloweeID] = make(map[int]struct{})
+	}
+	s.following[params.UserID][params.FolloweeID] = struct{}{}
+	s.followers[params.FolloweeID][params.UserID] = struct{}{}
+	return nil
+}
+
+func (s *serviceImpl) UnfollowUser(ctx context.Context, params api.UnfollowUserParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.follows(params.UserID, params.FolloweeID) {
+		return err(api.ErrorCodeNotFollowing, "user %d doesn't follow user %d", params.UserID, params.FolloweeID)
+	}
+	delete(s.following[params.UserID], params.FolloweeID)
+	delete(s.followers[params.FolloweeID], params.UserID)
+	return nil
+}
+
+func (s *serviceImpl) GetPrivacy(ctx context.Context, params api.GetPrivacyParams) (*api.Privacy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	activity, ok := s.privacy[params.UserID]
+	if !ok {
+		activity = api.ActivityVisibilityFollowers
+	}
+	return &api.Privacy{Activity: activity}, nil
+}
+
+func (s *serviceImpl) SetPrivacy(ctx context.Context, req *api.Privacy, params api.SetPrivacyParams) (*api.Privacy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.privacy[params.UserID] = req.Activity
+	return req, nil
+}
+
+func (s *serviceImpl) GetFeed(ctx context.Context, params api.GetFeedParams) (*api.FeedPage, error) {
+	// курсор - id последнего отданного события, события в ленте идут по возрастанию id
+	before := 0
+	if cursor, ok := params.Cursor.Get(); ok {
+		id, e := strconv.Atoi(cursor)
+		if e != nil || id < 1 {
+			return nil, err(api.ErrorCodeInvalidCursor, "invalid cursor %q", cursor)
+		}
+		before = id
+	}
+	limit := params.Limit.Or(20)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	page := api.FeedPage{Items: []api.Activity{}}
+	feed := s.feeds[params.UserID]
+	for i := len(feed) - 1; i >= 0; i-- {
+		activity := &feed[i]
+		if before != 0 && activity.ID >= before || !s.activityVisible(activity, params.UserID) {
+			continue
+		}
+		if len(page.Items) == limit {
+			page.NextCursor = api.NewOptString(strconv.Itoa(page.Items[limit-1].ID))
+			break
+		}
+		page.Items = append(page.Items, *activity)
+	}
+	return &page, nil
+}
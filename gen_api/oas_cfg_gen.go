@@ -3,6 +3,8 @@
 package api
 
 import (
+	"fmt"
+	"math/big"
 	"net/http"
 
 	"go.opentelemetry.io/otel"
@@ -15,6 +17,15 @@ import (
 	"github.com/ogen-go/ogen/otelogen"
 )
 
+var ratMap = map[string]*big.Rat{
+	"1/2": func() *big.Rat {
+		r, ok := new(big.Rat).SetString("1/2")
+		if !ok {
+			panic(fmt.Sprintf("rat %q: can't parse", "1/2"))
+		}
+		return r
+	}(),
+}
 var (
 	// Allocate option closure once.
 	clientSpanKind = trace.WithSpanKind(trace.SpanKindClient)
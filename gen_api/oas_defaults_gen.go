@@ -3,10 +3,82 @@
 package api
 
 // setDefaults set default value of fields.
-func (s *Error) setDefaults() {
+func (s *Contributor) setDefaults() {
 	{
-		val := string("")
-		s.Message = val
+		val := ContributorRole("author")
+		s.Role = val
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *Highlight) setDefaults() {
+	{
+		val := HighlightColor("yellow")
+		s.Color = val
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *Locale) setDefaults() {
+	{
+		val := Language("en")
+		s.Language = val
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *NewBook) setDefaults() {
+	{
+		val := int(1)
+		s.Page.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *NewContributor) setDefaults() {
+	{
+		val := ContributorRole("author")
+		s.Role.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *NewHighlight) setDefaults() {
+	{
+		val := HighlightColor("yellow")
+		s.Color.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *NewReadingPlan) setDefaults() {
+	{
+		val := string("UTC")
+		s.TimeZone.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *NewReview) setDefaults() {
+	{
+		val := ReviewVisibility("private")
+		s.Visibility.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *Privacy) setDefaults() {
+	{
+		val := ActivityVisibility("followers")
+		s.Activity = val
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *Review) setDefaults() {
+	{
+		val := ReviewVisibility("private")
+		s.Visibility = val
 	}
 }
 
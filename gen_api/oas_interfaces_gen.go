@@ -1,18 +1,18 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
-type AddUserBookRes interface {
-	addUserBookRes()
+type ExportUserBooksRes interface {
+	exportUserBooksRes()
 }
 
-type GetUserBookRes interface {
-	getUserBookRes()
+type GetBookCoverRes interface {
+	getBookCoverRes()
 }
 
-type RemoveUserBookRes interface {
-	removeUserBookRes()
+type ImportUserBooksReq interface {
+	importUserBooksReq()
 }
 
-type UpdateReadingProgressRes interface {
-	updateReadingProgressRes()
+type PutBookCoverReq interface {
+	putBookCoverReq()
 }
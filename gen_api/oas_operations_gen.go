@@ -6,9 +6,77 @@ package api
 type OperationName = string
 
 const (
+	AddBookBookmarkOperation       OperationName = "AddBookBookmark"
+	AddBookEditionOperation        OperationName = "AddBookEdition"
+	AddBookHighlightOperation      OperationName = "AddBookHighlight"
+	AddBookNoteOperation           OperationName = "AddBookNote"
+	AddBookToShelfOperation        OperationName = "AddBookToShelf"
 	AddUserBookOperation           OperationName = "AddUserBook"
+	AddUserBookEpubOperation       OperationName = "AddUserBookEpub"
+	AddUserShelfOperation          OperationName = "AddUserShelf"
+	CreateClubOperation            OperationName = "CreateClub"
+	ExportBookHighlightsOperation  OperationName = "ExportBookHighlights"
+	ExportReadingPlansOperation    OperationName = "ExportReadingPlans"
+	ExportUserBooksOperation       OperationName = "ExportUserBooks"
+	FindAuthorsOperation           OperationName = "FindAuthors"
+	FollowUserOperation            OperationName = "FollowUser"
+	GetAuthorOperation             OperationName = "GetAuthor"
+	GetBookBookmarkOperation       OperationName = "GetBookBookmark"
+	GetBookBookmarksOperation      OperationName = "GetBookBookmarks"
+	GetBookCoverOperation          OperationName = "GetBookCover"
+	GetBookHighlightOperation      OperationName = "GetBookHighlight"
+	GetBookHighlightsOperation     OperationName = "GetBookHighlights"
+	GetBookNoteOperation           OperationName = "GetBookNote"
+	GetBookNotesOperation          OperationName = "GetBookNotes"
+	GetBookReviewOperation         OperationName = "GetBookReview"
+	GetBookReviewsOperation        OperationName = "GetBookReviews"
+	GetClubOperation               OperationName = "GetClub"
+	GetClubEventsOperation         OperationName = "GetClubEvents"
+	GetClubProgressOperation       OperationName = "GetClubProgress"
+	GetFeedOperation               OperationName = "GetFeed"
+	GetFollowersOperation          OperationName = "GetFollowers"
+	GetFollowingOperation          OperationName = "GetFollowing"
+	GetLocaleOperation             OperationName = "GetLocale"
+	GetPrivacyOperation            OperationName = "GetPrivacy"
+	GetReadingPlanOperation        OperationName = "GetReadingPlan"
+	GetReadingQueueOperation       OperationName = "GetReadingQueue"
+	GetRecommendationsOperation    OperationName = "GetRecommendations"
 	GetUserBookOperation           OperationName = "GetUserBook"
 	GetUserBooksOperation          OperationName = "GetUserBooks"
+	GetUserBooksByAuthorOperation  OperationName = "GetUserBooksByAuthor"
+	GetUserSeriesOperation         OperationName = "GetUserSeries"
+	GetUserShelvesOperation        OperationName = "GetUserShelves"
+	GetUserStatsOperation          OperationName = "GetUserStats"
+	GetYearReportOperation         OperationName = "GetYearReport"
+	ImportGoodreadsOperation       OperationName = "ImportGoodreads"
+	ImportKindleClippingsOperation OperationName = "ImportKindleClippings"
+	ImportUserBooksOperation       OperationName = "ImportUserBooks"
+	InviteToClubOperation          OperationName = "InviteToClub"
+	JoinClubOperation              OperationName = "JoinClub"
+	LeaveClubOperation             OperationName = "LeaveClub"
+	MoveInReadingQueueOperation    OperationName = "MoveInReadingQueue"
+	PickClubBookOperation          OperationName = "PickClubBook"
+	PopReadingQueueOperation       OperationName = "PopReadingQueue"
+	PutBookCoverOperation          OperationName = "PutBookCover"
+	PutBookReviewOperation         OperationName = "PutBookReview"
+	RemoveBookBookmarkOperation    OperationName = "RemoveBookBookmark"
+	RemoveBookFromShelfOperation   OperationName = "RemoveBookFromShelf"
+	RemoveBookHighlightOperation   OperationName = "RemoveBookHighlight"
+	RemoveBookNoteOperation        OperationName = "RemoveBookNote"
+	RemoveBookReviewOperation      OperationName = "RemoveBookReview"
+	RemoveReadingPlanOperation     OperationName = "RemoveReadingPlan"
 	RemoveUserBookOperation        OperationName = "RemoveUserBook"
+	RemoveUserShelfOperation       OperationName = "RemoveUserShelf"
+	RenameUserShelfOperation       OperationName = "RenameUserShelf"
+	SearchUserBooksOperation       OperationName = "SearchUserBooks"
+	SetLocaleOperation             OperationName = "SetLocale"
+	SetPrivacyOperation            OperationName = "SetPrivacy"
+	SetReadingPlanOperation        OperationName = "SetReadingPlan"
+	StartRereadOperation           OperationName = "StartReread"
+	SwitchBookEditionOperation     OperationName = "SwitchBookEdition"
+	UnfollowUserOperation          OperationName = "UnfollowUser"
+	UpdateBookBookmarkOperation    OperationName = "UpdateBookBookmark"
+	UpdateBookHighlightOperation   OperationName = "UpdateBookHighlight"
+	UpdateBookNoteOperation        OperationName = "UpdateBookNote"
 	UpdateReadingProgressOperation OperationName = "UpdateReadingProgress"
 )
@@ -40,7 +40,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.notFound(w, r)
 		return
 	}
-	args := [2]string{}
+	args := [3]string{}
 
 	// Static code generated router with unwrapped path search.
 	switch {
@@ -49,30 +49,21 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 		switch elem[0] {
-		case '/': // Prefix: "/users/"
+		case '/': // Prefix: "/"
 
-			if l := len("/users/"); len(elem) >= l && elem[0:l] == "/users/" {
+			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 				elem = elem[l:]
 			} else {
 				break
 			}
 
-			// Param: "user_id"
-			// Match until "/"
-			idx := strings.IndexByte(elem, '/')
-			if idx < 0 {
-				idx = len(elem)
-			}
-			args[0] = elem[:idx]
-			elem = elem[idx:]
-
 			if len(elem) == 0 {
 				break
 			}
 			switch elem[0] {
-			case '/': // Prefix: "/books"
+			case 'a': // Prefix: "authors"
 
-				if l := len("/books"); len(elem) >= l && elem[0:l] == "/books" {
+				if l := len("authors"); len(elem) >= l && elem[0:l] == "authors" {
 					elem = elem[l:]
 				} else {
 					break
@@ -81,15 +72,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				if len(elem) == 0 {
 					switch r.Method {
 					case "GET":
-						s.handleGetUserBooksRequest([1]string{
-							args[0],
-						}, elemIsEscaped, w, r)
-					case "POST":
-						s.handleAddUserBookRequest([1]string{
-							args[0],
-						}, elemIsEscaped, w, r)
+						s.handleFindAuthorsRequest([0]string{}, elemIsEscaped, w, r)
 					default:
-						s.notAllowed(w, r, "GET,POST")
+						s.notAllowed(w, r, "GET")
 					}
 
 					return
@@ -103,174 +88,305 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 
-					// Param: "book_id"
+					// Param: "author_id"
 					// Leaf parameter, slashes are prohibited
 					idx := strings.IndexByte(elem, '/')
 					if idx >= 0 {
 						break
 					}
-					args[1] = elem
+					args[0] = elem
 					elem = ""
 
 					if len(elem) == 0 {
 						// Leaf node.
 						switch r.Method {
-						case "DELETE":
-							s.handleRemoveUserBookRequest([2]string{
+						case "GET":
+							s.handleGetAuthorRequest([1]string{
 								args[0],
-								args[1],
 							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
+
+			case 'b': // Prefix: "books/"
+
+				if l := len("books/"); len(elem) >= l && elem[0:l] == "books/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "book_id"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/reviews"
+
+					if l := len("/reviews"); len(elem) >= l && elem[0:l] == "/reviews" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
 						case "GET":
-							s.handleGetUserBookRequest([2]string{
+							s.handleGetBookReviewsRequest([1]string{
 								args[0],
-								args[1],
 							}, elemIsEscaped, w, r)
-						case "PUT":
-							s.handleUpdateReadingProgressRequest([2]string{
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
+
+			case 'c': // Prefix: "clubs"
+
+				if l := len("clubs"); len(elem) >= l && elem[0:l] == "clubs" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "POST":
+						s.handleCreateClubRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "club_id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleGetClubRequest([1]string{
 								args[0],
-								args[1],
 							}, elemIsEscaped, w, r)
 						default:
-							s.notAllowed(w, r, "DELETE,GET,PUT")
+							s.notAllowed(w, r, "GET")
 						}
 
 						return
 					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
 
-				}
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-			}
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'b': // Prefix: "book"
 
-		}
-	}
-	s.notFound(w, r)
-}
+							if l := len("book"); len(elem) >= l && elem[0:l] == "book" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-// Route is route object.
-type Route struct {
-	name        string
-	summary     string
-	operationID string
-	pathPattern string
-	count       int
-	args        [2]string
-}
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "PUT":
+									s.handlePickClubBookRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "PUT")
+								}
 
-// Name returns ogen operation name.
-//
-// It is guaranteed to be unique and not empty.
-func (r Route) Name() string {
-	return r.name
-}
+								return
+							}
 
-// Summary returns OpenAPI summary.
-func (r Route) Summary() string {
-	return r.summary
-}
+						case 'e': // Prefix: "events"
 
-// OperationID returns OpenAPI operationId.
-func (r Route) OperationID() string {
-	return r.operationID
-}
+							if l := len("events"); len(elem) >= l && elem[0:l] == "events" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-// PathPattern returns OpenAPI path.
-func (r Route) PathPattern() string {
-	return r.pathPattern
-}
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetClubEventsRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
 
-// Args returns parsed arguments.
-func (r Route) Args() []string {
-	return r.args[:r.count]
-}
+								return
+							}
 
-// FindRoute finds Route for given method and path.
-//
-// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
-func (s *Server) FindRoute(method, path string) (Route, bool) {
-	return s.FindPath(method, &url.URL{Path: path})
-}
+						case 'i': // Prefix: "invites"
 
-// FindPath finds Route for given method and URL.
-func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
-	var (
-		elem = u.Path
-		args = r.args
-	)
-	if rawPath := u.RawPath; rawPath != "" {
-		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
-			elem = normalized
-		}
-		defer func() {
-			for i, arg := range r.args[:r.count] {
-				if unescaped, err := url.PathUnescape(arg); err == nil {
-					r.args[i] = unescaped
-				}
-			}
-		}()
-	}
+							if l := len("invites"); len(elem) >= l && elem[0:l] == "invites" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-	elem, ok := s.cutPrefix(elem)
-	if !ok {
-		return r, false
-	}
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleInviteToClubRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
 
-	// Static code generated router with unwrapped path search.
-	switch {
-	default:
-		if len(elem) == 0 {
-			break
-		}
-		switch elem[0] {
-		case '/': // Prefix: "/users/"
+								return
+							}
 
-			if l := len("/users/"); len(elem) >= l && elem[0:l] == "/users/" {
-				elem = elem[l:]
-			} else {
-				break
-			}
+						case 'm': // Prefix: "members"
 
-			// Param: "user_id"
-			// Match until "/"
-			idx := strings.IndexByte(elem, '/')
-			if idx < 0 {
-				idx = len(elem)
-			}
-			args[0] = elem[:idx]
-			elem = elem[idx:]
+							if l := len("members"); len(elem) >= l && elem[0:l] == "members" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-			if len(elem) == 0 {
-				break
-			}
-			switch elem[0] {
-			case '/': // Prefix: "/books"
+							if len(elem) == 0 {
+								switch r.Method {
+								case "POST":
+									s.handleJoinClubRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "user_id"
+								// Leaf parameter, slashes are prohibited
+								idx := strings.IndexByte(elem, '/')
+								if idx >= 0 {
+									break
+								}
+								args[1] = elem
+								elem = ""
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "DELETE":
+										s.handleLeaveClubRequest([2]string{
+											args[0],
+											args[1],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "DELETE")
+									}
+
+									return
+								}
+
+							}
+
+						case 'p': // Prefix: "progress"
+
+							if l := len("progress"); len(elem) >= l && elem[0:l] == "progress" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-				if l := len("/books"); len(elem) >= l && elem[0:l] == "/books" {
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetClubProgressRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						}
+
+					}
+
+				}
+
+			case 'u': // Prefix: "users/"
+
+				if l := len("users/"); len(elem) >= l && elem[0:l] == "users/" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
+				// Param: "user_id"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
 				if len(elem) == 0 {
-					switch method {
-					case "GET":
-						r.name = GetUserBooksOperation
-						r.summary = "Get all user's books with current progresses"
-						r.operationID = "getUserBooks"
-						r.pathPattern = "/users/{user_id}/books"
-						r.args = args
-						r.count = 1
-						return r, true
-					case "POST":
-						r.name = AddUserBookOperation
-						r.summary = "Add a new book for user"
-						r.operationID = "addUserBook"
-						r.pathPattern = "/users/{user_id}/books"
-						r.args = args
-						r.count = 1
-						return r, true
-					default:
-						return
-					}
+					break
 				}
 				switch elem[0] {
 				case '/': // Prefix: "/"
@@ -281,45 +397,3115 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						break
 					}
 
-					// Param: "book_id"
-					// Leaf parameter, slashes are prohibited
-					idx := strings.IndexByte(elem, '/')
-					if idx >= 0 {
+					if len(elem) == 0 {
 						break
 					}
-					args[1] = elem
-					elem = ""
+					switch elem[0] {
+					case 'a': // Prefix: "authors/"
+
+						if l := len("authors/"); len(elem) >= l && elem[0:l] == "authors/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "author_id"
+						// Match until "/"
+						idx := strings.IndexByte(elem, '/')
+						if idx < 0 {
+							idx = len(elem)
+						}
+						args[1] = elem[:idx]
+						elem = elem[idx:]
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/books"
+
+							if l := len("/books"); len(elem) >= l && elem[0:l] == "/books" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetUserBooksByAuthorRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						}
+
+					case 'b': // Prefix: "books"
+
+						if l := len("books"); len(elem) >= l && elem[0:l] == "books" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleGetUserBooksRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							case "POST":
+								s.handleAddUserBookRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET,POST")
+							}
 
-					if len(elem) == 0 {
-						// Leaf node.
-						switch method {
-						case "DELETE":
-							r.name = RemoveUserBookOperation
-							r.summary = "Remove book (or complete reading)"
-							r.operationID = "removeUserBook"
-							r.pathPattern = "/users/{user_id}/books/{book_id}"
-							r.args = args
-							r.count = 2
-							return r, true
-						case "GET":
-							r.name = GetUserBookOperation
-							r.summary = "Get book by it's id"
-							r.operationID = "getUserBook"
-							r.pathPattern = "/users/{user_id}/books/{book_id}"
-							r.args = args
-							r.count = 2
-							return r, true
-						case "PUT":
-							r.name = UpdateReadingProgressOperation
-							r.summary = "Update reading progess with new current page"
-							r.operationID = "updateReadingProgress"
-							r.pathPattern = "/users/{user_id}/books/{book_id}"
-							r.args = args
-							r.count = 2
-							return r, true
-						default:
 							return
 						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'e': // Prefix: "e"
+								origElem := elem
+								if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'p': // Prefix: "pub"
+
+									if l := len("pub"); len(elem) >= l && elem[0:l] == "pub" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "POST":
+											s.handleAddUserBookEpubRequest([1]string{
+												args[0],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "POST")
+										}
+
+										return
+									}
+
+								case 'x': // Prefix: "xport"
+
+									if l := len("xport"); len(elem) >= l && elem[0:l] == "xport" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "GET":
+											s.handleExportUserBooksRequest([1]string{
+												args[0],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET")
+										}
+
+										return
+									}
+
+								}
+
+								elem = origElem
+							case 'i': // Prefix: "import"
+								origElem := elem
+								if l := len("import"); len(elem) >= l && elem[0:l] == "import" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch r.Method {
+									case "POST":
+										s.handleImportUserBooksRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/"
+
+									if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'g': // Prefix: "goodreads"
+
+										if l := len("goodreads"); len(elem) >= l && elem[0:l] == "goodreads" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "POST":
+												s.handleImportGoodreadsRequest([1]string{
+													args[0],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "POST")
+											}
+
+											return
+										}
+
+									case 'k': // Prefix: "kindle"
+
+										if l := len("kindle"); len(elem) >= l && elem[0:l] == "kindle" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "POST":
+												s.handleImportKindleClippingsRequest([1]string{
+													args[0],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "POST")
+											}
+
+											return
+										}
+
+									}
+
+								}
+
+								elem = origElem
+							case 's': // Prefix: "search"
+								origElem := elem
+								if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleSearchUserBooksRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+
+								elem = origElem
+							}
+							// Param: "book_id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[1] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "DELETE":
+									s.handleRemoveUserBookRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								case "GET":
+									s.handleGetUserBookRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								case "PUT":
+									s.handleUpdateReadingProgressRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "DELETE,GET,PUT")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'b': // Prefix: "bookmarks"
+
+									if l := len("bookmarks"); len(elem) >= l && elem[0:l] == "bookmarks" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch r.Method {
+										case "GET":
+											s.handleGetBookBookmarksRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "POST":
+											s.handleAddBookBookmarkRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET,POST")
+										}
+
+										return
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										// Param: "bookmark_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "DELETE":
+												s.handleRemoveBookBookmarkRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "GET":
+												s.handleGetBookBookmarkRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "PUT":
+												s.handleUpdateBookBookmarkRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "DELETE,GET,PUT")
+											}
+
+											return
+										}
+
+									}
+
+								case 'c': // Prefix: "cover"
+
+									if l := len("cover"); len(elem) >= l && elem[0:l] == "cover" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "GET":
+											s.handleGetBookCoverRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "PUT":
+											s.handlePutBookCoverRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET,PUT")
+										}
+
+										return
+									}
+
+								case 'e': // Prefix: "edition"
+
+									if l := len("edition"); len(elem) >= l && elem[0:l] == "edition" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch r.Method {
+										case "PUT":
+											s.handleSwitchBookEditionRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "PUT")
+										}
+
+										return
+									}
+									switch elem[0] {
+									case 's': // Prefix: "s"
+
+										if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "POST":
+												s.handleAddBookEditionRequest([2]string{
+													args[0],
+													args[1],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "POST")
+											}
+
+											return
+										}
+
+									}
+
+								case 'h': // Prefix: "highlights"
+
+									if l := len("highlights"); len(elem) >= l && elem[0:l] == "highlights" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch r.Method {
+										case "GET":
+											s.handleGetBookHighlightsRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "POST":
+											s.handleAddBookHighlightRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET,POST")
+										}
+
+										return
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											break
+										}
+										switch elem[0] {
+										case 'e': // Prefix: "export"
+											origElem := elem
+											if l := len("export"); len(elem) >= l && elem[0:l] == "export" {
+												elem = elem[l:]
+											} else {
+												break
+											}
+
+											if len(elem) == 0 {
+												// Leaf node.
+												switch r.Method {
+												case "GET":
+													s.handleExportBookHighlightsRequest([2]string{
+														args[0],
+														args[1],
+													}, elemIsEscaped, w, r)
+												default:
+													s.notAllowed(w, r, "GET")
+												}
+
+												return
+											}
+
+											elem = origElem
+										}
+										// Param: "highlight_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "DELETE":
+												s.handleRemoveBookHighlightRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "GET":
+												s.handleGetBookHighlightRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "PUT":
+												s.handleUpdateBookHighlightRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "DELETE,GET,PUT")
+											}
+
+											return
+										}
+
+									}
+
+								case 'n': // Prefix: "notes"
+
+									if l := len("notes"); len(elem) >= l && elem[0:l] == "notes" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch r.Method {
+										case "GET":
+											s.handleGetBookNotesRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "POST":
+											s.handleAddBookNoteRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET,POST")
+										}
+
+										return
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										// Param: "note_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "DELETE":
+												s.handleRemoveBookNoteRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "GET":
+												s.handleGetBookNoteRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											case "PUT":
+												s.handleUpdateBookNoteRequest([3]string{
+													args[0],
+													args[1],
+													args[2],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "DELETE,GET,PUT")
+											}
+
+											return
+										}
+
+									}
+
+								case 'p': // Prefix: "plan"
+
+									if l := len("plan"); len(elem) >= l && elem[0:l] == "plan" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "DELETE":
+											s.handleRemoveReadingPlanRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "GET":
+											s.handleGetReadingPlanRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "PUT":
+											s.handleSetReadingPlanRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "DELETE,GET,PUT")
+										}
+
+										return
+									}
+
+								case 'r': // Prefix: "re"
+
+									if l := len("re"); len(elem) >= l && elem[0:l] == "re" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'a': // Prefix: "ads"
+
+										if l := len("ads"); len(elem) >= l && elem[0:l] == "ads" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "POST":
+												s.handleStartRereadRequest([2]string{
+													args[0],
+													args[1],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "POST")
+											}
+
+											return
+										}
+
+									case 'v': // Prefix: "view"
+
+										if l := len("view"); len(elem) >= l && elem[0:l] == "view" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "DELETE":
+												s.handleRemoveBookReviewRequest([2]string{
+													args[0],
+													args[1],
+												}, elemIsEscaped, w, r)
+											case "GET":
+												s.handleGetBookReviewRequest([2]string{
+													args[0],
+													args[1],
+												}, elemIsEscaped, w, r)
+											case "PUT":
+												s.handlePutBookReviewRequest([2]string{
+													args[0],
+													args[1],
+												}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "DELETE,GET,PUT")
+											}
+
+											return
+										}
+
+									}
+
+								}
+
+							}
+
+						}
+
+					case 'f': // Prefix: "f"
+
+						if l := len("f"); len(elem) >= l && elem[0:l] == "f" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "eed"
+
+							if l := len("eed"); len(elem) >= l && elem[0:l] == "eed" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetFeedRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'o': // Prefix: "ollow"
+
+							if l := len("ollow"); len(elem) >= l && elem[0:l] == "ollow" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'e': // Prefix: "ers"
+
+								if l := len("ers"); len(elem) >= l && elem[0:l] == "ers" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleGetFollowersRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+
+							case 'i': // Prefix: "ing"
+
+								if l := len("ing"); len(elem) >= l && elem[0:l] == "ing" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch r.Method {
+									case "GET":
+										s.handleGetFollowingRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/"
+
+									if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									// Param: "followee_id"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[1] = elem
+									elem = ""
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "DELETE":
+											s.handleUnfollowUserRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										case "PUT":
+											s.handleFollowUserRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "DELETE,PUT")
+										}
+
+										return
+									}
+
+								}
+
+							}
+
+						}
+
+					case 'l': // Prefix: "locale"
+
+						if l := len("locale"); len(elem) >= l && elem[0:l] == "locale" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetLocaleRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							case "PUT":
+								s.handleSetLocaleRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET,PUT")
+							}
+
+							return
+						}
+
+					case 'p': // Prefix: "p"
+
+						if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'l': // Prefix: "lan.ics"
+
+							if l := len("lan.ics"); len(elem) >= l && elem[0:l] == "lan.ics" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleExportReadingPlansRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'r': // Prefix: "rivacy"
+
+							if l := len("rivacy"); len(elem) >= l && elem[0:l] == "rivacy" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetPrivacyRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								case "PUT":
+									s.handleSetPrivacyRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET,PUT")
+								}
+
+								return
+							}
+
+						}
+
+					case 'q': // Prefix: "queue"
+
+						if l := len("queue"); len(elem) >= l && elem[0:l] == "queue" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleGetReadingQueueRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'p': // Prefix: "pop"
+								origElem := elem
+								if l := len("pop"); len(elem) >= l && elem[0:l] == "pop" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "POST":
+										s.handlePopReadingQueueRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+
+								elem = origElem
+							}
+							// Param: "book_id"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[1] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "PUT":
+									s.handleMoveInReadingQueueRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "PUT")
+								}
+
+								return
+							}
+
+						}
+
+					case 'r': // Prefix: "re"
+
+						if l := len("re"); len(elem) >= l && elem[0:l] == "re" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'c': // Prefix: "commendations"
+
+							if l := len("commendations"); len(elem) >= l && elem[0:l] == "commendations" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetRecommendationsRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'p': // Prefix: "ports/"
+
+							if l := len("ports/"); len(elem) >= l && elem[0:l] == "ports/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "year"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[1] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetYearReportRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						}
+
+					case 's': // Prefix: "s"
+
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "eries"
+
+							if l := len("eries"); len(elem) >= l && elem[0:l] == "eries" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetUserSeriesRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'h': // Prefix: "helves"
+
+							if l := len("helves"); len(elem) >= l && elem[0:l] == "helves" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "GET":
+									s.handleGetUserShelvesRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								case "POST":
+									s.handleAddUserShelfRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET,POST")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "shelf_id"
+								// Match until "/"
+								idx := strings.IndexByte(elem, '/')
+								if idx < 0 {
+									idx = len(elem)
+								}
+								args[1] = elem[:idx]
+								elem = elem[idx:]
+
+								if len(elem) == 0 {
+									switch r.Method {
+									case "DELETE":
+										s.handleRemoveUserShelfRequest([2]string{
+											args[0],
+											args[1],
+										}, elemIsEscaped, w, r)
+									case "PUT":
+										s.handleRenameUserShelfRequest([2]string{
+											args[0],
+											args[1],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "DELETE,PUT")
+									}
+
+									return
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/books/"
+
+									if l := len("/books/"); len(elem) >= l && elem[0:l] == "/books/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									// Param: "book_id"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[2] = elem
+									elem = ""
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "DELETE":
+											s.handleRemoveBookFromShelfRequest([3]string{
+												args[0],
+												args[1],
+												args[2],
+											}, elemIsEscaped, w, r)
+										case "PUT":
+											s.handleAddBookToShelfRequest([3]string{
+												args[0],
+												args[1],
+												args[2],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "DELETE,PUT")
+										}
+
+										return
+									}
+
+								}
+
+							}
+
+						case 't': // Prefix: "tats"
+
+							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetUserStatsRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						}
+
+					}
+
+				}
+
+			}
+
+		}
+	}
+	s.notFound(w, r)
+}
+
+// Route is route object.
+type Route struct {
+	name        string
+	summary     string
+	operationID string
+	pathPattern string
+	count       int
+	args        [3]string
+}
+
+// Name returns ogen operation name.
+//
+// It is guaranteed to be unique and not empty.
+func (r Route) Name() string {
+	return r.name
+}
+
+// Summary returns OpenAPI summary.
+func (r Route) Summary() string {
+	return r.summary
+}
+
+// OperationID returns OpenAPI operationId.
+func (r Route) OperationID() string {
+	return r.operationID
+}
+
+// PathPattern returns OpenAPI path.
+func (r Route) PathPattern() string {
+	return r.pathPattern
+}
+
+// Args returns parsed arguments.
+func (r Route) Args() []string {
+	return r.args[:r.count]
+}
+
+// FindRoute finds Route for given method and path.
+//
+// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
+func (s *Server) FindRoute(method, path string) (Route, bool) {
+	return s.FindPath(method, &url.URL{Path: path})
+}
+
+// FindPath finds Route for given method and URL.
+func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
+	var (
+		elem = u.Path
+		args = r.args
+	)
+	if rawPath := u.RawPath; rawPath != "" {
+		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
+			elem = normalized
+		}
+		defer func() {
+			for i, arg := range r.args[:r.count] {
+				if unescaped, err := url.PathUnescape(arg); err == nil {
+					r.args[i] = unescaped
+				}
+			}
+		}()
+	}
+
+	elem, ok := s.cutPrefix(elem)
+	if !ok {
+		return r, false
+	}
+
+	// Static code generated router with unwrapped path search.
+	switch {
+	default:
+		if len(elem) == 0 {
+			break
+		}
+		switch elem[0] {
+		case '/': // Prefix: "/"
+
+			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+				elem = elem[l:]
+			} else {
+				break
+			}
+
+			if len(elem) == 0 {
+				break
+			}
+			switch elem[0] {
+			case 'a': // Prefix: "authors"
+
+				if l := len("authors"); len(elem) >= l && elem[0:l] == "authors" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = FindAuthorsOperation
+						r.summary = "Find authors by name"
+						r.operationID = "findAuthors"
+						r.pathPattern = "/authors"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "author_id"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetAuthorOperation
+							r.summary = "Get author by id"
+							r.operationID = "getAuthor"
+							r.pathPattern = "/authors/{author_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
+
+			case 'b': // Prefix: "books/"
+
+				if l := len("books/"); len(elem) >= l && elem[0:l] == "books/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "book_id"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/reviews"
+
+					if l := len("/reviews"); len(elem) >= l && elem[0:l] == "/reviews" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetBookReviewsOperation
+							r.summary = "Get reviews of the book"
+							r.operationID = "getBookReviews"
+							r.pathPattern = "/books/{book_id}/reviews"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
+
+			case 'c': // Prefix: "clubs"
+
+				if l := len("clubs"); len(elem) >= l && elem[0:l] == "clubs" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "POST":
+						r.name = CreateClubOperation
+						r.summary = "Create a club"
+						r.operationID = "createClub"
+						r.pathPattern = "/clubs"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "club_id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = GetClubOperation
+							r.summary = "Get club by id"
+							r.operationID = "getClub"
+							r.pathPattern = "/clubs/{club_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'b': // Prefix: "book"
+
+							if l := len("book"); len(elem) >= l && elem[0:l] == "book" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "PUT":
+									r.name = PickClubBookOperation
+									r.summary = "Pick the current book of the club"
+									r.operationID = "pickClubBook"
+									r.pathPattern = "/clubs/{club_id}/book"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'e': // Prefix: "events"
+
+							if l := len("events"); len(elem) >= l && elem[0:l] == "events" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetClubEventsOperation
+									r.summary = "Get club events"
+									r.operationID = "getClubEvents"
+									r.pathPattern = "/clubs/{club_id}/events"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'i': // Prefix: "invites"
+
+							if l := len("invites"); len(elem) >= l && elem[0:l] == "invites" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = InviteToClubOperation
+									r.summary = "Invite a user to the club"
+									r.operationID = "inviteToClub"
+									r.pathPattern = "/clubs/{club_id}/invites"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'm': // Prefix: "members"
+
+							if l := len("members"); len(elem) >= l && elem[0:l] == "members" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "POST":
+									r.name = JoinClubOperation
+									r.summary = "Join the club"
+									r.operationID = "joinClub"
+									r.pathPattern = "/clubs/{club_id}/members"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "user_id"
+								// Leaf parameter, slashes are prohibited
+								idx := strings.IndexByte(elem, '/')
+								if idx >= 0 {
+									break
+								}
+								args[1] = elem
+								elem = ""
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "DELETE":
+										r.name = LeaveClubOperation
+										r.summary = "Leave the club"
+										r.operationID = "leaveClub"
+										r.pathPattern = "/clubs/{club_id}/members/{user_id}"
+										r.args = args
+										r.count = 2
+										return r, true
+									default:
+										return
+									}
+								}
+
+							}
+
+						case 'p': // Prefix: "progress"
+
+							if l := len("progress"); len(elem) >= l && elem[0:l] == "progress" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetClubProgressOperation
+									r.summary = "Get members' progress on the current book"
+									r.operationID = "getClubProgress"
+									r.pathPattern = "/clubs/{club_id}/progress"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					}
+
+				}
+
+			case 'u': // Prefix: "users/"
+
+				if l := len("users/"); len(elem) >= l && elem[0:l] == "users/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "user_id"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "authors/"
+
+						if l := len("authors/"); len(elem) >= l && elem[0:l] == "authors/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "author_id"
+						// Match until "/"
+						idx := strings.IndexByte(elem, '/')
+						if idx < 0 {
+							idx = len(elem)
+						}
+						args[1] = elem[:idx]
+						elem = elem[idx:]
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/books"
+
+							if l := len("/books"); len(elem) >= l && elem[0:l] == "/books" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetUserBooksByAuthorOperation
+									r.summary = "Get user's books by author"
+									r.operationID = "getUserBooksByAuthor"
+									r.pathPattern = "/users/{user_id}/authors/{author_id}/books"
+									r.args = args
+									r.count = 2
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 'b': // Prefix: "books"
+
+						if l := len("books"); len(elem) >= l && elem[0:l] == "books" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = GetUserBooksOperation
+								r.summary = "Get all user's books with current progresses"
+								r.operationID = "getUserBooks"
+								r.pathPattern = "/users/{user_id}/books"
+								r.args = args
+								r.count = 1
+								return r, true
+							case "POST":
+								r.name = AddUserBookOperation
+								r.summary = "Add a new book for user"
+								r.operationID = "addUserBook"
+								r.pathPattern = "/users/{user_id}/books"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'e': // Prefix: "e"
+								origElem := elem
+								if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'p': // Prefix: "pub"
+
+									if l := len("pub"); len(elem) >= l && elem[0:l] == "pub" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "POST":
+											r.name = AddUserBookEpubOperation
+											r.summary = "Add a new book from EPUB file"
+											r.operationID = "addUserBookEpub"
+											r.pathPattern = "/users/{user_id}/books/epub"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 'x': // Prefix: "xport"
+
+									if l := len("xport"); len(elem) >= l && elem[0:l] == "xport" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "GET":
+											r.name = ExportUserBooksOperation
+											r.summary = "Export user's books"
+											r.operationID = "exportUserBooks"
+											r.pathPattern = "/users/{user_id}/books/export"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+
+								}
+
+								elem = origElem
+							case 'i': // Prefix: "import"
+								origElem := elem
+								if l := len("import"); len(elem) >= l && elem[0:l] == "import" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "POST":
+										r.name = ImportUserBooksOperation
+										r.summary = "Import books to user's shelf"
+										r.operationID = "importUserBooks"
+										r.pathPattern = "/users/{user_id}/books/import"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/"
+
+									if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'g': // Prefix: "goodreads"
+
+										if l := len("goodreads"); len(elem) >= l && elem[0:l] == "goodreads" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "POST":
+												r.name = ImportGoodreadsOperation
+												r.summary = "Import Goodreads library"
+												r.operationID = "importGoodreads"
+												r.pathPattern = "/users/{user_id}/books/import/goodreads"
+												r.args = args
+												r.count = 1
+												return r, true
+											default:
+												return
+											}
+										}
+
+									case 'k': // Prefix: "kindle"
+
+										if l := len("kindle"); len(elem) >= l && elem[0:l] == "kindle" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "POST":
+												r.name = ImportKindleClippingsOperation
+												r.summary = "Import Kindle highlights"
+												r.operationID = "importKindleClippings"
+												r.pathPattern = "/users/{user_id}/books/import/kindle"
+												r.args = args
+												r.count = 1
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								}
+
+								elem = origElem
+							case 's': // Prefix: "search"
+								origElem := elem
+								if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "GET":
+										r.name = SearchUserBooksOperation
+										r.summary = "Full-text search over user's books"
+										r.operationID = "searchUserBooks"
+										r.pathPattern = "/users/{user_id}/books/search"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+
+								elem = origElem
+							}
+							// Param: "book_id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[1] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								switch method {
+								case "DELETE":
+									r.name = RemoveUserBookOperation
+									r.summary = "Remove book (or complete reading)"
+									r.operationID = "removeUserBook"
+									r.pathPattern = "/users/{user_id}/books/{book_id}"
+									r.args = args
+									r.count = 2
+									return r, true
+								case "GET":
+									r.name = GetUserBookOperation
+									r.summary = "Get book by it's id"
+									r.operationID = "getUserBook"
+									r.pathPattern = "/users/{user_id}/books/{book_id}"
+									r.args = args
+									r.count = 2
+									return r, true
+								case "PUT":
+									r.name = UpdateReadingProgressOperation
+									r.summary = "Update reading progess with new current page"
+									r.operationID = "updateReadingProgress"
+									r.pathPattern = "/users/{user_id}/books/{book_id}"
+									r.args = args
+									r.count = 2
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'b': // Prefix: "bookmarks"
+
+									if l := len("bookmarks"); len(elem) >= l && elem[0:l] == "bookmarks" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "GET":
+											r.name = GetBookBookmarksOperation
+											r.summary = "Get bookmarks of the book"
+											r.operationID = "getBookBookmarks"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/bookmarks"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "POST":
+											r.name = AddBookBookmarkOperation
+											r.summary = "Add a bookmark to the book"
+											r.operationID = "addBookBookmark"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/bookmarks"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										// Param: "bookmark_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = RemoveBookBookmarkOperation
+												r.summary = "Remove bookmark"
+												r.operationID = "removeBookBookmark"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "GET":
+												r.name = GetBookBookmarkOperation
+												r.summary = "Get bookmark by it's id"
+												r.operationID = "getBookBookmark"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "PUT":
+												r.name = UpdateBookBookmarkOperation
+												r.summary = "Update bookmark"
+												r.operationID = "updateBookBookmark"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								case 'c': // Prefix: "cover"
+
+									if l := len("cover"); len(elem) >= l && elem[0:l] == "cover" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "GET":
+											r.name = GetBookCoverOperation
+											r.summary = "Get cover of the book"
+											r.operationID = "getBookCover"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/cover"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "PUT":
+											r.name = PutBookCoverOperation
+											r.summary = "Upload cover of the book"
+											r.operationID = "putBookCover"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/cover"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 'e': // Prefix: "edition"
+
+									if l := len("edition"); len(elem) >= l && elem[0:l] == "edition" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "PUT":
+											r.name = SwitchBookEditionOperation
+											r.summary = "Switch to another edition of a book"
+											r.operationID = "switchBookEdition"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/edition"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+									switch elem[0] {
+									case 's': // Prefix: "s"
+
+										if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "POST":
+												r.name = AddBookEditionOperation
+												r.summary = "Add an edition of a book"
+												r.operationID = "addBookEdition"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/editions"
+												r.args = args
+												r.count = 2
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								case 'h': // Prefix: "highlights"
+
+									if l := len("highlights"); len(elem) >= l && elem[0:l] == "highlights" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "GET":
+											r.name = GetBookHighlightsOperation
+											r.summary = "Get highlights of the book"
+											r.operationID = "getBookHighlights"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/highlights"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "POST":
+											r.name = AddBookHighlightOperation
+											r.summary = "Add a highlight to the book"
+											r.operationID = "addBookHighlight"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/highlights"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											break
+										}
+										switch elem[0] {
+										case 'e': // Prefix: "export"
+											origElem := elem
+											if l := len("export"); len(elem) >= l && elem[0:l] == "export" {
+												elem = elem[l:]
+											} else {
+												break
+											}
+
+											if len(elem) == 0 {
+												// Leaf node.
+												switch method {
+												case "GET":
+													r.name = ExportBookHighlightsOperation
+													r.summary = "Export highlights as Markdown"
+													r.operationID = "exportBookHighlights"
+													r.pathPattern = "/users/{user_id}/books/{book_id}/highlights/export"
+													r.args = args
+													r.count = 2
+													return r, true
+												default:
+													return
+												}
+											}
+
+											elem = origElem
+										}
+										// Param: "highlight_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = RemoveBookHighlightOperation
+												r.summary = "Remove highlight"
+												r.operationID = "removeBookHighlight"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/highlights/{highlight_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "GET":
+												r.name = GetBookHighlightOperation
+												r.summary = "Get highlight by it's id"
+												r.operationID = "getBookHighlight"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/highlights/{highlight_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "PUT":
+												r.name = UpdateBookHighlightOperation
+												r.summary = "Update highlight"
+												r.operationID = "updateBookHighlight"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/highlights/{highlight_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								case 'n': // Prefix: "notes"
+
+									if l := len("notes"); len(elem) >= l && elem[0:l] == "notes" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "GET":
+											r.name = GetBookNotesOperation
+											r.summary = "Get notes of the book"
+											r.operationID = "getBookNotes"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/notes"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "POST":
+											r.name = AddBookNoteOperation
+											r.summary = "Add a note to the book"
+											r.operationID = "addBookNote"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/notes"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+									switch elem[0] {
+									case '/': // Prefix: "/"
+
+										if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										// Param: "note_id"
+										// Leaf parameter, slashes are prohibited
+										idx := strings.IndexByte(elem, '/')
+										if idx >= 0 {
+											break
+										}
+										args[2] = elem
+										elem = ""
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = RemoveBookNoteOperation
+												r.summary = "Remove note"
+												r.operationID = "removeBookNote"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/notes/{note_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "GET":
+												r.name = GetBookNoteOperation
+												r.summary = "Get note by it's id"
+												r.operationID = "getBookNote"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/notes/{note_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											case "PUT":
+												r.name = UpdateBookNoteOperation
+												r.summary = "Update note"
+												r.operationID = "updateBookNote"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/notes/{note_id}"
+												r.args = args
+												r.count = 3
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								case 'p': // Prefix: "plan"
+
+									if l := len("plan"); len(elem) >= l && elem[0:l] == "plan" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "DELETE":
+											r.name = RemoveReadingPlanOperation
+											r.summary = "Remove reading plan of a book"
+											r.operationID = "removeReadingPlan"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/plan"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "GET":
+											r.name = GetReadingPlanOperation
+											r.summary = "Get reading plan of a book"
+											r.operationID = "getReadingPlan"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/plan"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "PUT":
+											r.name = SetReadingPlanOperation
+											r.summary = "Set reading plan of a book"
+											r.operationID = "setReadingPlan"
+											r.pathPattern = "/users/{user_id}/books/{book_id}/plan"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 'r': // Prefix: "re"
+
+									if l := len("re"); len(elem) >= l && elem[0:l] == "re" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'a': // Prefix: "ads"
+
+										if l := len("ads"); len(elem) >= l && elem[0:l] == "ads" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "POST":
+												r.name = StartRereadOperation
+												r.summary = "Start rereading a book"
+												r.operationID = "startReread"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/reads"
+												r.args = args
+												r.count = 2
+												return r, true
+											default:
+												return
+											}
+										}
+
+									case 'v': // Prefix: "view"
+
+										if l := len("view"); len(elem) >= l && elem[0:l] == "view" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = RemoveBookReviewOperation
+												r.summary = "Remove review of the book"
+												r.operationID = "removeBookReview"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/review"
+												r.args = args
+												r.count = 2
+												return r, true
+											case "GET":
+												r.name = GetBookReviewOperation
+												r.summary = "Get review of the book"
+												r.operationID = "getBookReview"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/review"
+												r.args = args
+												r.count = 2
+												return r, true
+											case "PUT":
+												r.name = PutBookReviewOperation
+												r.summary = "Review the book"
+												r.operationID = "putBookReview"
+												r.pathPattern = "/users/{user_id}/books/{book_id}/review"
+												r.args = args
+												r.count = 2
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								}
+
+							}
+
+						}
+
+					case 'f': // Prefix: "f"
+
+						if l := len("f"); len(elem) >= l && elem[0:l] == "f" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "eed"
+
+							if l := len("eed"); len(elem) >= l && elem[0:l] == "eed" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetFeedOperation
+									r.summary = "Get activity feed"
+									r.operationID = "getFeed"
+									r.pathPattern = "/users/{user_id}/feed"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'o': // Prefix: "ollow"
+
+							if l := len("ollow"); len(elem) >= l && elem[0:l] == "ollow" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'e': // Prefix: "ers"
+
+								if l := len("ers"); len(elem) >= l && elem[0:l] == "ers" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "GET":
+										r.name = GetFollowersOperation
+										r.summary = "Get followers of the user"
+										r.operationID = "getFollowers"
+										r.pathPattern = "/users/{user_id}/followers"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+
+							case 'i': // Prefix: "ing"
+
+								if l := len("ing"); len(elem) >= l && elem[0:l] == "ing" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "GET":
+										r.name = GetFollowingOperation
+										r.summary = "Get users the user follows"
+										r.operationID = "getFollowing"
+										r.pathPattern = "/users/{user_id}/following"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/"
+
+									if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									// Param: "followee_id"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[1] = elem
+									elem = ""
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "DELETE":
+											r.name = UnfollowUserOperation
+											r.summary = "Unfollow a user"
+											r.operationID = "unfollowUser"
+											r.pathPattern = "/users/{user_id}/following/{followee_id}"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "PUT":
+											r.name = FollowUserOperation
+											r.summary = "Follow a user"
+											r.operationID = "followUser"
+											r.pathPattern = "/users/{user_id}/following/{followee_id}"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+
+								}
+
+							}
+
+						}
+
+					case 'l': // Prefix: "locale"
+
+						if l := len("locale"); len(elem) >= l && elem[0:l] == "locale" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetLocaleOperation
+								r.summary = "Get user's language"
+								r.operationID = "getLocale"
+								r.pathPattern = "/users/{user_id}/locale"
+								r.args = args
+								r.count = 1
+								return r, true
+							case "PUT":
+								r.name = SetLocaleOperation
+								r.summary = "Change user's language"
+								r.operationID = "setLocale"
+								r.pathPattern = "/users/{user_id}/locale"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'p': // Prefix: "p"
+
+						if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'l': // Prefix: "lan.ics"
+
+							if l := len("lan.ics"); len(elem) >= l && elem[0:l] == "lan.ics" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = ExportReadingPlansOperation
+									r.summary = "Export reading plans as iCalendar"
+									r.operationID = "exportReadingPlans"
+									r.pathPattern = "/users/{user_id}/plan.ics"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'r': // Prefix: "rivacy"
+
+							if l := len("rivacy"); len(elem) >= l && elem[0:l] == "rivacy" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetPrivacyOperation
+									r.summary = "Get privacy settings"
+									r.operationID = "getPrivacy"
+									r.pathPattern = "/users/{user_id}/privacy"
+									r.args = args
+									r.count = 1
+									return r, true
+								case "PUT":
+									r.name = SetPrivacyOperation
+									r.summary = "Change privacy settings"
+									r.operationID = "setPrivacy"
+									r.pathPattern = "/users/{user_id}/privacy"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 'q': // Prefix: "queue"
+
+						if l := len("queue"); len(elem) >= l && elem[0:l] == "queue" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = GetReadingQueueOperation
+								r.summary = "Get the queue of books to read next"
+								r.operationID = "getReadingQueue"
+								r.pathPattern = "/users/{user_id}/queue"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'p': // Prefix: "pop"
+								origElem := elem
+								if l := len("pop"); len(elem) >= l && elem[0:l] == "pop" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "POST":
+										r.name = PopReadingQueueOperation
+										r.summary = "Start reading the next book"
+										r.operationID = "popReadingQueue"
+										r.pathPattern = "/users/{user_id}/queue/pop"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+
+								elem = origElem
+							}
+							// Param: "book_id"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[1] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "PUT":
+									r.name = MoveInReadingQueueOperation
+									r.summary = "Move a book in the queue"
+									r.operationID = "moveInReadingQueue"
+									r.pathPattern = "/users/{user_id}/queue/{book_id}"
+									r.args = args
+									r.count = 2
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 'r': // Prefix: "re"
+
+						if l := len("re"); len(elem) >= l && elem[0:l] == "re" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'c': // Prefix: "commendations"
+
+							if l := len("commendations"); len(elem) >= l && elem[0:l] == "commendations" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetRecommendationsOperation
+									r.summary = "Get book recommendations"
+									r.operationID = "getRecommendations"
+									r.pathPattern = "/users/{user_id}/recommendations"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'p': // Prefix: "ports/"
+
+							if l := len("ports/"); len(elem) >= l && elem[0:l] == "ports/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "year"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[1] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetYearReportOperation
+									r.summary = "Get user's year in review"
+									r.operationID = "getYearReport"
+									r.pathPattern = "/users/{user_id}/reports/{year}"
+									r.args = args
+									r.count = 2
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 's': // Prefix: "s"
+
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "eries"
+
+							if l := len("eries"); len(elem) >= l && elem[0:l] == "eries" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetUserSeriesOperation
+									r.summary = "Get user's books grouped by series"
+									r.operationID = "getUserSeries"
+									r.pathPattern = "/users/{user_id}/series"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'h': // Prefix: "helves"
+
+							if l := len("helves"); len(elem) >= l && elem[0:l] == "helves" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									r.name = GetUserShelvesOperation
+									r.summary = "Get user's shelves"
+									r.operationID = "getUserShelves"
+									r.pathPattern = "/users/{user_id}/shelves"
+									r.args = args
+									r.count = 1
+									return r, true
+								case "POST":
+									r.name = AddUserShelfOperation
+									r.summary = "Create a shelf"
+									r.operationID = "addUserShelf"
+									r.pathPattern = "/users/{user_id}/shelves"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "shelf_id"
+								// Match until "/"
+								idx := strings.IndexByte(elem, '/')
+								if idx < 0 {
+									idx = len(elem)
+								}
+								args[1] = elem[:idx]
+								elem = elem[idx:]
+
+								if len(elem) == 0 {
+									switch method {
+									case "DELETE":
+										r.name = RemoveUserShelfOperation
+										r.summary = "Remove a shelf"
+										r.operationID = "removeUserShelf"
+										r.pathPattern = "/users/{user_id}/shelves/{shelf_id}"
+										r.args = args
+										r.count = 2
+										return r, true
+									case "PUT":
+										r.name = RenameUserShelfOperation
+										r.summary = "Rename a shelf"
+										r.operationID = "renameUserShelf"
+										r.pathPattern = "/users/{user_id}/shelves/{shelf_id}"
+										r.args = args
+										r.count = 2
+										return r, true
+									default:
+										return
+									}
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/books/"
+
+									if l := len("/books/"); len(elem) >= l && elem[0:l] == "/books/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									// Param: "book_id"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[2] = elem
+									elem = ""
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "DELETE":
+											r.name = RemoveBookFromShelfOperation
+											r.summary = "Take a book off a shelf"
+											r.operationID = "removeBookFromShelf"
+											r.pathPattern = "/users/{user_id}/shelves/{shelf_id}/books/{book_id}"
+											r.args = args
+											r.count = 3
+											return r, true
+										case "PUT":
+											r.name = AddBookToShelfOperation
+											r.summary = "Put a book on a shelf"
+											r.operationID = "addBookToShelf"
+											r.pathPattern = "/users/{user_id}/shelves/{shelf_id}/books/{book_id}"
+											r.args = args
+											r.count = 3
+											return r, true
+										default:
+											return
+										}
+									}
+
+								}
+
+							}
+
+						case 't': // Prefix: "tats"
+
+							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetUserStatsOperation
+									r.summary = "Get user's reading statistics"
+									r.operationID = "getUserStats"
+									r.pathPattern = "/users/{user_id}/stats"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
 					}
 
 				}
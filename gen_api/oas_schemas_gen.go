@@ -3,22 +3,353 @@
 package api
 
 import (
+	"fmt"
+	"io"
 	"time"
+
+	"github.com/go-faster/errors"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+func (s *ErrorStatusCode) Error() string {
+	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
+}
+
+// Something a followed user did.
+// Ref: #/components/schemas/Activity
+type Activity struct {
+	ID     int          `json:"id"`
+	UserID int          `json:"user_id"`
+	Type   ActivityType `json:"type"`
+	At     time.Time    `json:"at"`
+	BookID int          `json:"book_id"`
+	Title  string       `json:"title"`
+	Author OptString    `json:"author"`
+	Rating OptRating    `json:"rating"`
+}
+
+// GetID returns the value of ID.
+func (s *Activity) GetID() int {
+	return s.ID
+}
+
+// GetUserID returns the value of UserID.
+func (s *Activity) GetUserID() int {
+	return s.UserID
+}
+
+// GetType returns the value of Type.
+func (s *Activity) GetType() ActivityType {
+	return s.Type
+}
+
+// GetAt returns the value of At.
+func (s *Activity) GetAt() time.Time {
+	return s.At
+}
+
+// GetBookID returns the value of BookID.
+func (s *Activity) GetBookID() int {
+	return s.BookID
+}
+
+// GetTitle returns the value of Title.
+func (s *Activity) GetTitle() string {
+	return s.Title
+}
+
+// GetAuthor returns the value of Author.
+func (s *Activity) GetAuthor() OptString {
+	return s.Author
+}
+
+// GetRating returns the value of Rating.
+func (s *Activity) GetRating() OptRating {
+	return s.Rating
+}
+
+// SetID sets the value of ID.
+func (s *Activity) SetID(val int) {
+	s.ID = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *Activity) SetUserID(val int) {
+	s.UserID = val
+}
+
+// SetType sets the value of Type.
+func (s *Activity) SetType(val ActivityType) {
+	s.Type = val
+}
+
+// SetAt sets the value of At.
+func (s *Activity) SetAt(val time.Time) {
+	s.At = val
+}
+
+// SetBookID sets the value of BookID.
+func (s *Activity) SetBookID(val int) {
+	s.BookID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Activity) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetAuthor sets the value of Author.
+func (s *Activity) SetAuthor(val OptString) {
+	s.Author = val
+}
+
+// SetRating sets the value of Rating.
+func (s *Activity) SetRating(val OptRating) {
+	s.Rating = val
+}
+
+// Ref: #/components/schemas/ActivityType
+type ActivityType string
+
+const (
+	ActivityTypeStarted  ActivityType = "started"
+	ActivityTypeFinished ActivityType = "finished"
+	ActivityTypeRated    ActivityType = "rated"
+)
+
+// AllValues returns all ActivityType values.
+func (ActivityType) AllValues() []ActivityType {
+	return []ActivityType{
+		ActivityTypeStarted,
+		ActivityTypeFinished,
+		ActivityTypeRated,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ActivityType) MarshalText() ([]byte, error) {
+	switch s {
+	case ActivityTypeStarted:
+		return []byte(s), nil
+	case ActivityTypeFinished:
+		return []byte(s), nil
+	case ActivityTypeRated:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ActivityType) UnmarshalText(data []byte) error {
+	switch ActivityType(data) {
+	case ActivityTypeStarted:
+		*s = ActivityTypeStarted
+		return nil
+	case ActivityTypeFinished:
+		*s = ActivityTypeFinished
+		return nil
+	case ActivityTypeRated:
+		*s = ActivityTypeRated
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Who sees user's activity in the feed.
+// Ref: #/components/schemas/ActivityVisibility
+type ActivityVisibility string
+
+const (
+	ActivityVisibilityFollowers ActivityVisibility = "followers"
+	ActivityVisibilityNobody    ActivityVisibility = "nobody"
 )
 
+// AllValues returns all ActivityVisibility values.
+func (ActivityVisibility) AllValues() []ActivityVisibility {
+	return []ActivityVisibility{
+		ActivityVisibilityFollowers,
+		ActivityVisibilityNobody,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ActivityVisibility) MarshalText() ([]byte, error) {
+	switch s {
+	case ActivityVisibilityFollowers:
+		return []byte(s), nil
+	case ActivityVisibilityNobody:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ActivityVisibility) UnmarshalText(data []byte) error {
+	switch ActivityVisibility(data) {
+	case ActivityVisibilityFollowers:
+		*s = ActivityVisibilityFollowers
+		return nil
+	case ActivityVisibilityNobody:
+		*s = ActivityVisibilityNobody
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// AddBookToShelfNoContent is response for AddBookToShelf operation.
+type AddBookToShelfNoContent struct{}
+
+type AddUserBookEpubReq struct {
+	// EPUB file.
+	File ht.MultipartFile `json:"file"`
+	// ID of the book from common database, derived from title and author if omitted.
+	ID OptInt `json:"id"`
+}
+
+// GetFile returns the value of File.
+func (s *AddUserBookEpubReq) GetFile() ht.MultipartFile {
+	return s.File
+}
+
+// GetID returns the value of ID.
+func (s *AddUserBookEpubReq) GetID() OptInt {
+	return s.ID
+}
+
+// SetFile sets the value of File.
+func (s *AddUserBookEpubReq) SetFile(val ht.MultipartFile) {
+	s.File = val
+}
+
+// SetID sets the value of ID.
+func (s *AddUserBookEpubReq) SetID(val OptInt) {
+	s.ID = val
+}
+
+// Author, translator or editor of books, shared by all users.
+// Ref: #/components/schemas/Author
+type Author struct {
+	// Unique ID of the author.
+	ID int `json:"id"`
+	// Name as it was first met.
+	Name string `json:"name"`
+	// All spellings of the name met in books.
+	Variants []string `json:"variants"`
+}
+
+// GetID returns the value of ID.
+func (s *Author) GetID() int {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Author) GetName() string {
+	return s.Name
+}
+
+// GetVariants returns the value of Variants.
+func (s *Author) GetVariants() []string {
+	return s.Variants
+}
+
+// SetID sets the value of ID.
+func (s *Author) SetID(val int) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Author) SetName(val string) {
+	s.Name = val
+}
+
+// SetVariants sets the value of Variants.
+func (s *Author) SetVariants(val []string) {
+	s.Variants = val
+}
+
+// Ref: #/components/schemas/AuthorCount
+type AuthorCount struct {
+	AuthorID int    `json:"author_id"`
+	Name     string `json:"name"`
+	// Finished books of the author.
+	Books int `json:"books"`
+}
+
+// GetAuthorID returns the value of AuthorID.
+func (s *AuthorCount) GetAuthorID() int {
+	return s.AuthorID
+}
+
+// GetName returns the value of Name.
+func (s *AuthorCount) GetName() string {
+	return s.Name
+}
+
+// GetBooks returns the value of Books.
+func (s *AuthorCount) GetBooks() int {
+	return s.Books
+}
+
+// SetAuthorID sets the value of AuthorID.
+func (s *AuthorCount) SetAuthorID(val int) {
+	s.AuthorID = val
+}
+
+// SetName sets the value of Name.
+func (s *AuthorCount) SetName(val string) {
+	s.Name = val
+}
+
+// SetBooks sets the value of Books.
+func (s *AuthorCount) SetBooks(val int) {
+	s.Books = val
+}
+
 // Book structrure.
 // Ref: #/components/schemas/Book
 type Book struct {
 	// Uniqie ID of the book from common database.
 	ID int `json:"id"`
-	// Current page user is reading.
+	// Current page user is reading, or position in another unit if the edition being read uses it.
 	Page int `json:"page"`
 	// Title of the book.
 	Title string `json:"title"`
-	// Author of the book.
+	// Authors of the book as they are written on the cover.
 	Author string `json:"author"`
-	// Publication date.
-	Published time.Time `json:"published"`
+	// Authors, translators and editors of the book.
+	Contributors []Contributor `json:"contributors"`
+	// Publication date, may be unknown for imported books.
+	Published OptDate `json:"published"`
+	// Number of pages in the book.
+	Pages OptInt `json:"pages"`
+	// Language of the book as BCP 47 tag.
+	Language OptString     `json:"language"`
+	Status   ReadingStatus `json:"status"`
+	// Date the book was read.
+	Finished OptDate `json:"finished"`
+	// ISBN-10 without hyphens.
+	Isbn10 OptString `json:"isbn10"`
+	// ISBN-13 without hyphens.
+	Isbn13  OptString        `json:"isbn13"`
+	Ratings OptRatingSummary `json:"ratings"`
+	Series  OptSeries        `json:"series"`
+	// Editions of the book, the user reads one of them.
+	Editions []Edition `json:"editions"`
+	// ID of the edition the user reads, page and read-throughs' pages are in its unit.
+	Edition OptInt          `json:"edition"`
+	Unit    OptProgressUnit `json:"unit"`
+	// Read part of the book from 0 to 1, absent if the length of the book is unknown.
+	Progress OptFloat64 `json:"progress"`
+	// Read-throughs of the book from the first one, page, status and finished of the book describe the
+	// last one.
+	Reads []ReadThrough `json:"reads"`
+	// Names of user's shelves the book is on.
+	Shelves []string `json:"shelves"`
 }
 
 // GetID returns the value of ID.
@@ -41,11 +372,86 @@ func (s *Book) GetAuthor() string {
 	return s.Author
 }
 
+// GetContributors returns the value of Contributors.
+func (s *Book) GetContributors() []Contributor {
+	return s.Contributors
+}
+
 // GetPublished returns the value of Published.
-func (s *Book) GetPublished() time.Time {
+func (s *Book) GetPublished() OptDate {
 	return s.Published
 }
 
+// GetPages returns the value of Pages.
+func (s *Book) GetPages() OptInt {
+	return s.Pages
+}
+
+// GetLanguage returns the value of Language.
+func (s *Book) GetLanguage() OptString {
+	return s.Language
+}
+
+// GetStatus returns the value of Status.
+func (s *Book) GetStatus() ReadingStatus {
+	return s.Status
+}
+
+// GetFinished returns the value of Finished.
+func (s *Book) GetFinished() OptDate {
+	return s.Finished
+}
+
+// GetIsbn10 returns the value of Isbn10.
+func (s *Book) GetIsbn10() OptString {
+	return s.Isbn10
+}
+
+// GetIsbn13 returns the value of Isbn13.
+func (s *Book) GetIsbn13() OptString {
+	return s.Isbn13
+}
+
+// GetRatings returns the value of Ratings.
+func (s *Book) GetRatings() OptRatingSummary {
+	return s.Ratings
+}
+
+// GetSeries returns the value of Series.
+func (s *Book) GetSeries() OptSeries {
+	return s.Series
+}
+
+// GetEditions returns the value of Editions.
+func (s *Book) GetEditions() []Edition {
+	return s.Editions
+}
+
+// GetEdition returns the value of Edition.
+func (s *Book) GetEdition() OptInt {
+	return s.Edition
+}
+
+// GetUnit returns the value of Unit.
+func (s *Book) GetUnit() OptProgressUnit {
+	return s.Unit
+}
+
+// GetProgress returns the value of Progress.
+func (s *Book) GetProgress() OptFloat64 {
+	return s.Progress
+}
+
+// GetReads returns the value of Reads.
+func (s *Book) GetReads() []ReadThrough {
+	return s.Reads
+}
+
+// GetShelves returns the value of Shelves.
+func (s *Book) GetShelves() []string {
+	return s.Shelves
+}
+
 // SetID sets the value of ID.
 func (s *Book) SetID(val int) {
 	s.ID = val
@@ -66,65 +472,4697 @@ func (s *Book) SetAuthor(val string) {
 	s.Author = val
 }
 
+// SetContributors sets the value of Contributors.
+func (s *Book) SetContributors(val []Contributor) {
+	s.Contributors = val
+}
+
 // SetPublished sets the value of Published.
-func (s *Book) SetPublished(val time.Time) {
+func (s *Book) SetPublished(val OptDate) {
 	s.Published = val
 }
 
-func (*Book) addUserBookRes()           {}
-func (*Book) getUserBookRes()           {}
-func (*Book) updateReadingProgressRes() {}
+// SetPages sets the value of Pages.
+func (s *Book) SetPages(val OptInt) {
+	s.Pages = val
+}
 
-// Error.
-// Ref: #/components/schemas/Error
-type Error struct {
-	// HTTP error code.
-	StatusCode int `json:"status_code"`
-	// Error description.
-	Message string `json:"message"`
+// SetLanguage sets the value of Language.
+func (s *Book) SetLanguage(val OptString) {
+	s.Language = val
 }
 
-// GetStatusCode returns the value of StatusCode.
-func (s *Error) GetStatusCode() int {
-	return s.StatusCode
+// SetStatus sets the value of Status.
+func (s *Book) SetStatus(val ReadingStatus) {
+	s.Status = val
 }
 
-// GetMessage returns the value of Message.
-func (s *Error) GetMessage() string {
-	return s.Message
+// SetFinished sets the value of Finished.
+func (s *Book) SetFinished(val OptDate) {
+	s.Finished = val
 }
 
-// SetStatusCode sets the value of StatusCode.
-func (s *Error) SetStatusCode(val int) {
-	s.StatusCode = val
+// SetIsbn10 sets the value of Isbn10.
+func (s *Book) SetIsbn10(val OptString) {
+	s.Isbn10 = val
 }
 
-// SetMessage sets the value of Message.
-func (s *Error) SetMessage(val string) {
-	s.Message = val
+// SetIsbn13 sets the value of Isbn13.
+func (s *Book) SetIsbn13(val OptString) {
+	s.Isbn13 = val
 }
 
-func (*Error) addUserBookRes()           {}
-func (*Error) getUserBookRes()           {}
-func (*Error) removeUserBookRes()        {}
-func (*Error) updateReadingProgressRes() {}
+// SetRatings sets the value of Ratings.
+func (s *Book) SetRatings(val OptRatingSummary) {
+	s.Ratings = val
+}
 
-// RemoveUserBookNoContent is response for RemoveUserBook operation.
-type RemoveUserBookNoContent struct{}
+// SetSeries sets the value of Series.
+func (s *Book) SetSeries(val OptSeries) {
+	s.Series = val
+}
 
-func (*RemoveUserBookNoContent) removeUserBookRes() {}
+// SetEditions sets the value of Editions.
+func (s *Book) SetEditions(val []Edition) {
+	s.Editions = val
+}
 
-type UpdateReadingProgressReq struct {
-	// New current page.
-	Page int `json:"page"`
+// SetEdition sets the value of Edition.
+func (s *Book) SetEdition(val OptInt) {
+	s.Edition = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *Book) SetUnit(val OptProgressUnit) {
+	s.Unit = val
+}
+
+// SetProgress sets the value of Progress.
+func (s *Book) SetProgress(val OptFloat64) {
+	s.Progress = val
+}
+
+// SetReads sets the value of Reads.
+func (s *Book) SetReads(val []ReadThrough) {
+	s.Reads = val
+}
+
+// SetShelves sets the value of Shelves.
+func (s *Book) SetShelves(val []string) {
+	s.Shelves = val
+}
+
+// Ref: #/components/schemas/BookReviews
+type BookReviews struct {
+	Ratings RatingSummary `json:"ratings"`
+	Reviews []Review      `json:"reviews"`
+}
+
+// GetRatings returns the value of Ratings.
+func (s *BookReviews) GetRatings() RatingSummary {
+	return s.Ratings
+}
+
+// GetReviews returns the value of Reviews.
+func (s *BookReviews) GetReviews() []Review {
+	return s.Reviews
+}
+
+// SetRatings sets the value of Ratings.
+func (s *BookReviews) SetRatings(val RatingSummary) {
+	s.Ratings = val
+}
+
+// SetReviews sets the value of Reviews.
+func (s *BookReviews) SetReviews(val []Review) {
+	s.Reviews = val
+}
+
+// Bookmark in a book.
+// Ref: #/components/schemas/Bookmark
+type Bookmark struct {
+	// Unique ID of the bookmark.
+	ID int `json:"id"`
+	// Name of the bookmark.
+	Title OptString `json:"title"`
+	// Page of the bookmark.
+	Page OptInt `json:"page"`
+	// E-reader location of the bookmark.
+	Location OptInt `json:"location"`
+	// When the bookmark was made.
+	Added time.Time `json:"added"`
+}
+
+// GetID returns the value of ID.
+func (s *Bookmark) GetID() int {
+	return s.ID
+}
+
+// GetTitle returns the value of Title.
+func (s *Bookmark) GetTitle() OptString {
+	return s.Title
 }
 
 // GetPage returns the value of Page.
-func (s *UpdateReadingProgressReq) GetPage() int {
+func (s *Bookmark) GetPage() OptInt {
 	return s.Page
 }
 
+// GetLocation returns the value of Location.
+func (s *Bookmark) GetLocation() OptInt {
+	return s.Location
+}
+
+// GetAdded returns the value of Added.
+func (s *Bookmark) GetAdded() time.Time {
+	return s.Added
+}
+
+// SetID sets the value of ID.
+func (s *Bookmark) SetID(val int) {
+	s.ID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Bookmark) SetTitle(val OptString) {
+	s.Title = val
+}
+
 // SetPage sets the value of Page.
-func (s *UpdateReadingProgressReq) SetPage(val int) {
+func (s *Bookmark) SetPage(val OptInt) {
 	s.Page = val
 }
+
+// SetLocation sets the value of Location.
+func (s *Bookmark) SetLocation(val OptInt) {
+	s.Location = val
+}
+
+// SetAdded sets the value of Added.
+func (s *Bookmark) SetAdded(val time.Time) {
+	s.Added = val
+}
+
+// Reading club of several users reading one book at a time.
+// Ref: #/components/schemas/Club
+type Club struct {
+	// Unique ID of the club.
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// ID of the user who created the club.
+	Owner   int   `json:"owner"`
+	Members []int `json:"members"`
+	// Users invited but not joined yet.
+	Invited []int `json:"invited"`
+	// Book the club reads now, IDs of books are shared by all users.
+	BookID     OptInt      `json:"book_id"`
+	Milestones []Milestone `json:"milestones"`
+}
+
+// GetID returns the value of ID.
+func (s *Club) GetID() int {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Club) GetName() string {
+	return s.Name
+}
+
+// GetOwner returns the value of Owner.
+func (s *Club) GetOwner() int {
+	return s.Owner
+}
+
+// GetMembers returns the value of Members.
+func (s *Club) GetMembers() []int {
+	return s.Members
+}
+
+// GetInvited returns the value of Invited.
+func (s *Club) GetInvited() []int {
+	return s.Invited
+}
+
+// GetBookID returns the value of BookID.
+func (s *Club) GetBookID() OptInt {
+	return s.BookID
+}
+
+// GetMilestones returns the value of Milestones.
+func (s *Club) GetMilestones() []Milestone {
+	return s.Milestones
+}
+
+// SetID sets the value of ID.
+func (s *Club) SetID(val int) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Club) SetName(val string) {
+	s.Name = val
+}
+
+// SetOwner sets the value of Owner.
+func (s *Club) SetOwner(val int) {
+	s.Owner = val
+}
+
+// SetMembers sets the value of Members.
+func (s *Club) SetMembers(val []int) {
+	s.Members = val
+}
+
+// SetInvited sets the value of Invited.
+func (s *Club) SetInvited(val []int) {
+	s.Invited = val
+}
+
+// SetBookID sets the value of BookID.
+func (s *Club) SetBookID(val OptInt) {
+	s.BookID = val
+}
+
+// SetMilestones sets the value of Milestones.
+func (s *Club) SetMilestones(val []Milestone) {
+	s.Milestones = val
+}
+
+// Ref: #/components/schemas/ClubBook
+type ClubBook struct {
+	BookID int `json:"book_id"`
+	// Milestones of the book, by default quarters of it.
+	Milestones []NewMilestone `json:"milestones"`
+}
+
+// GetBookID returns the value of BookID.
+func (s *ClubBook) GetBookID() int {
+	return s.BookID
+}
+
+// GetMilestones returns the value of Milestones.
+func (s *ClubBook) GetMilestones() []NewMilestone {
+	return s.Milestones
+}
+
+// SetBookID sets the value of BookID.
+func (s *ClubBook) SetBookID(val int) {
+	s.BookID = val
+}
+
+// SetMilestones sets the value of Milestones.
+func (s *ClubBook) SetMilestones(val []NewMilestone) {
+	s.Milestones = val
+}
+
+// Ref: #/components/schemas/ClubEvent
+type ClubEvent struct {
+	ID   int           `json:"id"`
+	Type ClubEventType `json:"type"`
+	At   time.Time     `json:"at"`
+	// Member who joined or left.
+	UserID OptInt `json:"user_id"`
+	BookID OptInt `json:"book_id"`
+	// Name of the reached milestone.
+	Milestone OptString `json:"milestone"`
+}
+
+// GetID returns the value of ID.
+func (s *ClubEvent) GetID() int {
+	return s.ID
+}
+
+// GetType returns the value of Type.
+func (s *ClubEvent) GetType() ClubEventType {
+	return s.Type
+}
+
+// GetAt returns the value of At.
+func (s *ClubEvent) GetAt() time.Time {
+	return s.At
+}
+
+// GetUserID returns the value of UserID.
+func (s *ClubEvent) GetUserID() OptInt {
+	return s.UserID
+}
+
+// GetBookID returns the value of BookID.
+func (s *ClubEvent) GetBookID() OptInt {
+	return s.BookID
+}
+
+// GetMilestone returns the value of Milestone.
+func (s *ClubEvent) GetMilestone() OptString {
+	return s.Milestone
+}
+
+// SetID sets the value of ID.
+func (s *ClubEvent) SetID(val int) {
+	s.ID = val
+}
+
+// SetType sets the value of Type.
+func (s *ClubEvent) SetType(val ClubEventType) {
+	s.Type = val
+}
+
+// SetAt sets the value of At.
+func (s *ClubEvent) SetAt(val time.Time) {
+	s.At = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *ClubEvent) SetUserID(val OptInt) {
+	s.UserID = val
+}
+
+// SetBookID sets the value of BookID.
+func (s *ClubEvent) SetBookID(val OptInt) {
+	s.BookID = val
+}
+
+// SetMilestone sets the value of Milestone.
+func (s *ClubEvent) SetMilestone(val OptString) {
+	s.Milestone = val
+}
+
+// Ref: #/components/schemas/ClubEventType
+type ClubEventType string
+
+const (
+	ClubEventTypeMemberJoined     ClubEventType = "member_joined"
+	ClubEventTypeMemberLeft       ClubEventType = "member_left"
+	ClubEventTypeBookPicked       ClubEventType = "book_picked"
+	ClubEventTypeMilestoneReached ClubEventType = "milestone_reached"
+)
+
+// AllValues returns all ClubEventType values.
+func (ClubEventType) AllValues() []ClubEventType {
+	return []ClubEventType{
+		ClubEventTypeMemberJoined,
+		ClubEventTypeMemberLeft,
+		ClubEventTypeBookPicked,
+		ClubEventTypeMilestoneReached,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ClubEventType) MarshalText() ([]byte, error) {
+	switch s {
+	case ClubEventTypeMemberJoined:
+		return []byte(s), nil
+	case ClubEventTypeMemberLeft:
+		return []byte(s), nil
+	case ClubEventTypeBookPicked:
+		return []byte(s), nil
+	case ClubEventTypeMilestoneReached:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ClubEventType) UnmarshalText(data []byte) error {
+	switch ClubEventType(data) {
+	case ClubEventTypeMemberJoined:
+		*s = ClubEventTypeMemberJoined
+		return nil
+	case ClubEventTypeMemberLeft:
+		*s = ClubEventTypeMemberLeft
+		return nil
+	case ClubEventTypeBookPicked:
+		*s = ClubEventTypeBookPicked
+		return nil
+	case ClubEventTypeMilestoneReached:
+		*s = ClubEventTypeMilestoneReached
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ClubUser
+type ClubUser struct {
+	UserID int `json:"user_id"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *ClubUser) GetUserID() int {
+	return s.UserID
+}
+
+// SetUserID sets the value of UserID.
+func (s *ClubUser) SetUserID(val int) {
+	s.UserID = val
+}
+
+// How to import books the user already has.
+// Ref: #/components/schemas/ConflictPolicy
+type ConflictPolicy string
+
+const (
+	ConflictPolicySkip      ConflictPolicy = "skip"
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+	ConflictPolicyFail      ConflictPolicy = "fail"
+)
+
+// AllValues returns all ConflictPolicy values.
+func (ConflictPolicy) AllValues() []ConflictPolicy {
+	return []ConflictPolicy{
+		ConflictPolicySkip,
+		ConflictPolicyOverwrite,
+		ConflictPolicyFail,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ConflictPolicy) MarshalText() ([]byte, error) {
+	switch s {
+	case ConflictPolicySkip:
+		return []byte(s), nil
+	case ConflictPolicyOverwrite:
+		return []byte(s), nil
+	case ConflictPolicyFail:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ConflictPolicy) UnmarshalText(data []byte) error {
+	switch ConflictPolicy(data) {
+	case ConflictPolicySkip:
+		*s = ConflictPolicySkip
+		return nil
+	case ConflictPolicyOverwrite:
+		*s = ConflictPolicyOverwrite
+		return nil
+	case ConflictPolicyFail:
+		*s = ConflictPolicyFail
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Person who took part in making the book.
+// Ref: #/components/schemas/Contributor
+type Contributor struct {
+	// ID of the author.
+	AuthorID int `json:"author_id"`
+	// Name as it is spelled in this book.
+	Name string          `json:"name"`
+	Role ContributorRole `json:"role"`
+}
+
+// GetAuthorID returns the value of AuthorID.
+func (s *Contributor) GetAuthorID() int {
+	return s.AuthorID
+}
+
+// GetName returns the value of Name.
+func (s *Contributor) GetName() string {
+	return s.Name
+}
+
+// GetRole returns the value of Role.
+func (s *Contributor) GetRole() ContributorRole {
+	return s.Role
+}
+
+// SetAuthorID sets the value of AuthorID.
+func (s *Contributor) SetAuthorID(val int) {
+	s.AuthorID = val
+}
+
+// SetName sets the value of Name.
+func (s *Contributor) SetName(val string) {
+	s.Name = val
+}
+
+// SetRole sets the value of Role.
+func (s *Contributor) SetRole(val ContributorRole) {
+	s.Role = val
+}
+
+// Ref: #/components/schemas/ContributorRole
+type ContributorRole string
+
+const (
+	ContributorRoleAuthor     ContributorRole = "author"
+	ContributorRoleTranslator ContributorRole = "translator"
+	ContributorRoleEditor     ContributorRole = "editor"
+)
+
+// AllValues returns all ContributorRole values.
+func (ContributorRole) AllValues() []ContributorRole {
+	return []ContributorRole{
+		ContributorRoleAuthor,
+		ContributorRoleTranslator,
+		ContributorRoleEditor,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ContributorRole) MarshalText() ([]byte, error) {
+	switch s {
+	case ContributorRoleAuthor:
+		return []byte(s), nil
+	case ContributorRoleTranslator:
+		return []byte(s), nil
+	case ContributorRoleEditor:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ContributorRole) UnmarshalText(data []byte) error {
+	switch ContributorRole(data) {
+	case ContributorRoleAuthor:
+		*s = ContributorRoleAuthor
+		return nil
+	case ContributorRoleTranslator:
+		*s = ContributorRoleTranslator
+		return nil
+	case ContributorRoleEditor:
+		*s = ContributorRoleEditor
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Cover image of the book.
+// Ref: #/components/schemas/Cover
+type Cover struct {
+	// ETag of the original image as getBookCover sends it, with quotes, can be sent in If-None-Match as
+	// is.
+	Etag string `json:"etag"`
+	// MIME type of the image.
+	ContentType string `json:"content_type"`
+	// Size of the image in bytes.
+	Size int `json:"size"`
+	// Width in pixels.
+	Width int `json:"width"`
+	// Height in pixels.
+	Height int `json:"height"`
+}
+
+// GetEtag returns the value of Etag.
+func (s *Cover) GetEtag() string {
+	return s.Etag
+}
+
+// GetContentType returns the value of ContentType.
+func (s *Cover) GetContentType() string {
+	return s.ContentType
+}
+
+// GetSize returns the value of Size.
+func (s *Cover) GetSize() int {
+	return s.Size
+}
+
+// GetWidth returns the value of Width.
+func (s *Cover) GetWidth() int {
+	return s.Width
+}
+
+// GetHeight returns the value of Height.
+func (s *Cover) GetHeight() int {
+	return s.Height
+}
+
+// SetEtag sets the value of Etag.
+func (s *Cover) SetEtag(val string) {
+	s.Etag = val
+}
+
+// SetContentType sets the value of ContentType.
+func (s *Cover) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetSize sets the value of Size.
+func (s *Cover) SetSize(val int) {
+	s.Size = val
+}
+
+// SetWidth sets the value of Width.
+func (s *Cover) SetWidth(val int) {
+	s.Width = val
+}
+
+// SetHeight sets the value of Height.
+func (s *Cover) SetHeight(val int) {
+	s.Height = val
+}
+
+// Ref: #/components/schemas/DayPages
+type DayPages struct {
+	Date  time.Time `json:"date"`
+	Pages int       `json:"pages"`
+}
+
+// GetDate returns the value of Date.
+func (s *DayPages) GetDate() time.Time {
+	return s.Date
+}
+
+// GetPages returns the value of Pages.
+func (s *DayPages) GetPages() int {
+	return s.Pages
+}
+
+// SetDate sets the value of Date.
+func (s *DayPages) SetDate(val time.Time) {
+	s.Date = val
+}
+
+// SetPages sets the value of Pages.
+func (s *DayPages) SetPages(val int) {
+	s.Pages = val
+}
+
+// Edition of the book.
+// Ref: #/components/schemas/Edition
+type Edition struct {
+	// ID of the edition, unique within the book.
+	ID     int             `json:"id"`
+	Format EditionFormat   `json:"format"`
+	Unit   OptProgressUnit `json:"unit"`
+	// Length of the edition in its unit - pages, locations or seconds. Not needed for percent.
+	Length OptInt `json:"length"`
+	// ISBN-13 of the edition without hyphens.
+	Isbn13 OptString `json:"isbn13"`
+}
+
+// GetID returns the value of ID.
+func (s *Edition) GetID() int {
+	return s.ID
+}
+
+// GetFormat returns the value of Format.
+func (s *Edition) GetFormat() EditionFormat {
+	return s.Format
+}
+
+// GetUnit returns the value of Unit.
+func (s *Edition) GetUnit() OptProgressUnit {
+	return s.Unit
+}
+
+// GetLength returns the value of Length.
+func (s *Edition) GetLength() OptInt {
+	return s.Length
+}
+
+// GetIsbn13 returns the value of Isbn13.
+func (s *Edition) GetIsbn13() OptString {
+	return s.Isbn13
+}
+
+// SetID sets the value of ID.
+func (s *Edition) SetID(val int) {
+	s.ID = val
+}
+
+// SetFormat sets the value of Format.
+func (s *Edition) SetFormat(val EditionFormat) {
+	s.Format = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *Edition) SetUnit(val OptProgressUnit) {
+	s.Unit = val
+}
+
+// SetLength sets the value of Length.
+func (s *Edition) SetLength(val OptInt) {
+	s.Length = val
+}
+
+// SetIsbn13 sets the value of Isbn13.
+func (s *Edition) SetIsbn13(val OptString) {
+	s.Isbn13 = val
+}
+
+// Ref: #/components/schemas/EditionFormat
+type EditionFormat string
+
+const (
+	EditionFormatHardcover EditionFormat = "hardcover"
+	EditionFormatPaperback EditionFormat = "paperback"
+	EditionFormatEbook     EditionFormat = "ebook"
+	EditionFormatAudiobook EditionFormat = "audiobook"
+)
+
+// AllValues returns all EditionFormat values.
+func (EditionFormat) AllValues() []EditionFormat {
+	return []EditionFormat{
+		EditionFormatHardcover,
+		EditionFormatPaperback,
+		EditionFormatEbook,
+		EditionFormatAudiobook,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s EditionFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case EditionFormatHardcover:
+		return []byte(s), nil
+	case EditionFormatPaperback:
+		return []byte(s), nil
+	case EditionFormatEbook:
+		return []byte(s), nil
+	case EditionFormatAudiobook:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *EditionFormat) UnmarshalText(data []byte) error {
+	switch EditionFormat(data) {
+	case EditionFormatHardcover:
+		*s = EditionFormatHardcover
+		return nil
+	case EditionFormatPaperback:
+		*s = EditionFormatPaperback
+		return nil
+	case EditionFormatEbook:
+		*s = EditionFormatEbook
+		return nil
+	case EditionFormatAudiobook:
+		*s = EditionFormatAudiobook
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/EditionSwitch
+type EditionSwitch struct {
+	// ID of the edition to read.
+	Edition int `json:"edition"`
+}
+
+// GetEdition returns the value of Edition.
+func (s *EditionSwitch) GetEdition() int {
+	return s.Edition
+}
+
+// SetEdition sets the value of Edition.
+func (s *EditionSwitch) SetEdition(val int) {
+	s.Edition = val
+}
+
+// Error in the RFC 7807 problem details format.
+// Ref: #/components/schemas/Error
+type Error struct {
+	// URI identifying the kind of error, derived from code.
+	Type string `json:"type"`
+	// Short summary of the kind of error, the same for every occurrence.
+	Title string `json:"title"`
+	// HTTP status code.
+	Status int `json:"status"`
+	// Explanation of this occurrence of the error.
+	Detail OptString `json:"detail"`
+	// Path of the request that caused the error.
+	Instance OptString `json:"instance"`
+	Code     ErrorCode `json:"code"`
+	// Invalid fields of the request.
+	Errors []FieldError `json:"errors"`
+}
+
+// GetType returns the value of Type.
+func (s *Error) GetType() string {
+	return s.Type
+}
+
+// GetTitle returns the value of Title.
+func (s *Error) GetTitle() string {
+	return s.Title
+}
+
+// GetStatus returns the value of Status.
+func (s *Error) GetStatus() int {
+	return s.Status
+}
+
+// GetDetail returns the value of Detail.
+func (s *Error) GetDetail() OptString {
+	return s.Detail
+}
+
+// GetInstance returns the value of Instance.
+func (s *Error) GetInstance() OptString {
+	return s.Instance
+}
+
+// GetCode returns the value of Code.
+func (s *Error) GetCode() ErrorCode {
+	return s.Code
+}
+
+// GetErrors returns the value of Errors.
+func (s *Error) GetErrors() []FieldError {
+	return s.Errors
+}
+
+// SetType sets the value of Type.
+func (s *Error) SetType(val string) {
+	s.Type = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Error) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Error) SetStatus(val int) {
+	s.Status = val
+}
+
+// SetDetail sets the value of Detail.
+func (s *Error) SetDetail(val OptString) {
+	s.Detail = val
+}
+
+// SetInstance sets the value of Instance.
+func (s *Error) SetInstance(val OptString) {
+	s.Instance = val
+}
+
+// SetCode sets the value of Code.
+func (s *Error) SetCode(val ErrorCode) {
+	s.Code = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *Error) SetErrors(val []FieldError) {
+	s.Errors = val
+}
+
+// Machine-readable kind of error, stable across releases.
+// Ref: #/components/schemas/ErrorCode
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidRequest       ErrorCode = "invalid_request"
+	ErrorCodeUnknownTimeZone      ErrorCode = "unknown_time_zone"
+	ErrorCodeInvalidCursor        ErrorCode = "invalid_cursor"
+	ErrorCodeInvalidRange         ErrorCode = "invalid_range"
+	ErrorCodeEmptyQuery           ErrorCode = "empty_query"
+	ErrorCodeSelfFollow           ErrorCode = "self_follow"
+	ErrorCodeUserNotFound         ErrorCode = "user_not_found"
+	ErrorCodeBookNotFound         ErrorCode = "book_not_found"
+	ErrorCodeNoteNotFound         ErrorCode = "note_not_found"
+	ErrorCodeHighlightNotFound    ErrorCode = "highlight_not_found"
+	ErrorCodeBookmarkNotFound     ErrorCode = "bookmark_not_found"
+	ErrorCodeAuthorNotFound       ErrorCode = "author_not_found"
+	ErrorCodeClubNotFound         ErrorCode = "club_not_found"
+	ErrorCodeMemberNotFound       ErrorCode = "member_not_found"
+	ErrorCodeShelfNotFound        ErrorCode = "shelf_not_found"
+	ErrorCodeBookNotOnShelf       ErrorCode = "book_not_on_shelf"
+	ErrorCodeCoverNotFound        ErrorCode = "cover_not_found"
+	ErrorCodeEditionNotFound      ErrorCode = "edition_not_found"
+	ErrorCodePlanNotFound         ErrorCode = "plan_not_found"
+	ErrorCodeReviewNotFound       ErrorCode = "review_not_found"
+	ErrorCodeQueueEmpty           ErrorCode = "queue_empty"
+	ErrorCodeNotFollowing         ErrorCode = "not_following"
+	ErrorCodeBookExists           ErrorCode = "book_exists"
+	ErrorCodeAlreadyReading       ErrorCode = "already_reading"
+	ErrorCodeBookNotRead          ErrorCode = "book_not_read"
+	ErrorCodeBookRead             ErrorCode = "book_read"
+	ErrorCodeNotInQueue           ErrorCode = "not_in_queue"
+	ErrorCodeShelfExists          ErrorCode = "shelf_exists"
+	ErrorCodeLengthUnknown        ErrorCode = "length_unknown"
+	ErrorCodeAlreadyMember        ErrorCode = "already_member"
+	ErrorCodeAlreadyInvited       ErrorCode = "already_invited"
+	ErrorCodeNotInvited           ErrorCode = "not_invited"
+	ErrorCodeOwnerCannotLeave     ErrorCode = "owner_cannot_leave"
+	ErrorCodeNoCurrentBook        ErrorCode = "no_current_book"
+	ErrorCodeImageTooLarge        ErrorCode = "image_too_large"
+	ErrorCodeNotFound             ErrorCode = "not_found"
+	ErrorCodeMethodNotAllowed     ErrorCode = "method_not_allowed"
+	ErrorCodeUnsupportedMediaType ErrorCode = "unsupported_media_type"
+	ErrorCodeNotImplemented       ErrorCode = "not_implemented"
+	ErrorCodeInternal             ErrorCode = "internal"
+)
+
+// AllValues returns all ErrorCode values.
+func (ErrorCode) AllValues() []ErrorCode {
+	return []ErrorCode{
+		ErrorCodeInvalidRequest,
+		ErrorCodeUnknownTimeZone,
+		ErrorCodeInvalidCursor,
+		ErrorCodeInvalidRange,
+		ErrorCodeEmptyQuery,
+		ErrorCodeSelfFollow,
+		ErrorCodeUserNotFound,
+		ErrorCodeBookNotFound,
+		ErrorCodeNoteNotFound,
+		ErrorCodeHighlightNotFound,
+		ErrorCodeBookmarkNotFound,
+		ErrorCodeAuthorNotFound,
+		ErrorCodeClubNotFound,
+		ErrorCodeMemberNotFound,
+		ErrorCodeShelfNotFound,
+		ErrorCodeBookNotOnShelf,
+		ErrorCodeCoverNotFound,
+		ErrorCodeEditionNotFound,
+		ErrorCodePlanNotFound,
+		ErrorCodeReviewNotFound,
+		ErrorCodeQueueEmpty,
+		ErrorCodeNotFollowing,
+		ErrorCodeBookExists,
+		ErrorCodeAlreadyReading,
+		ErrorCodeBookNotRead,
+		ErrorCodeBookRead,
+		ErrorCodeNotInQueue,
+		ErrorCodeShelfExists,
+		ErrorCodeLengthUnknown,
+		ErrorCodeAlreadyMember,
+		ErrorCodeAlreadyInvited,
+		ErrorCodeNotInvited,
+		ErrorCodeOwnerCannotLeave,
+		ErrorCodeNoCurrentBook,
+		ErrorCodeImageTooLarge,
+		ErrorCodeNotFound,
+		ErrorCodeMethodNotAllowed,
+		ErrorCodeUnsupportedMediaType,
+		ErrorCodeNotImplemented,
+		ErrorCodeInternal,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ErrorCode) MarshalText() ([]byte, error) {
+	switch s {
+	case ErrorCodeInvalidRequest:
+		return []byte(s), nil
+	case ErrorCodeUnknownTimeZone:
+		return []byte(s), nil
+	case ErrorCodeInvalidCursor:
+		return []byte(s), nil
+	case ErrorCodeInvalidRange:
+		return []byte(s), nil
+	case ErrorCodeEmptyQuery:
+		return []byte(s), nil
+	case ErrorCodeSelfFollow:
+		return []byte(s), nil
+	case ErrorCodeUserNotFound:
+		return []byte(s), nil
+	case ErrorCodeBookNotFound:
+		return []byte(s), nil
+	case ErrorCodeNoteNotFound:
+		return []byte(s), nil
+	case ErrorCodeHighlightNotFound:
+		return []byte(s), nil
+	case ErrorCodeBookmarkNotFound:
+		return []byte(s), nil
+	case ErrorCodeAuthorNotFound:
+		return []byte(s), nil
+	case ErrorCodeClubNotFound:
+		return []byte(s), nil
+	case ErrorCodeMemberNotFound:
+		return []byte(s), nil
+	case ErrorCodeShelfNotFound:
+		return []byte(s), nil
+	case ErrorCodeBookNotOnShelf:
+		return []byte(s), nil
+	case ErrorCodeCoverNotFound:
+		return []byte(s), nil
+	case ErrorCodeEditionNotFound:
+		return []byte(s), nil
+	case ErrorCodePlanNotFound:
+		return []byte(s), nil
+	case ErrorCodeReviewNotFound:
+		return []byte(s), nil
+	case ErrorCodeQueueEmpty:
+		return []byte(s), nil
+	case ErrorCodeNotFollowing:
+		return []byte(s), nil
+	case ErrorCodeBookExists:
+		return []byte(s), nil
+	case ErrorCodeAlreadyReading:
+		return []byte(s), nil
+	case ErrorCodeBookNotRead:
+		return []byte(s), nil
+	case ErrorCodeBookRead:
+		return []byte(s), nil
+	case ErrorCodeNotInQueue:
+		return []byte(s), nil
+	case ErrorCodeShelfExists:
+		return []byte(s), nil
+	case ErrorCodeLengthUnknown:
+		return []byte(s), nil
+	case ErrorCodeAlreadyMember:
+		return []byte(s), nil
+	case ErrorCodeAlreadyInvited:
+		return []byte(s), nil
+	case ErrorCodeNotInvited:
+		return []byte(s), nil
+	case ErrorCodeOwnerCannotLeave:
+		return []byte(s), nil
+	case ErrorCodeNoCurrentBook:
+		return []byte(s), nil
+	case ErrorCodeImageTooLarge:
+		return []byte(s), nil
+	case ErrorCodeNotFound:
+		return []byte(s), nil
+	case ErrorCodeMethodNotAllowed:
+		return []byte(s), nil
+	case ErrorCodeUnsupportedMediaType:
+		return []byte(s), nil
+	case ErrorCodeNotImplemented:
+		return []byte(s), nil
+	case ErrorCodeInternal:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ErrorCode) UnmarshalText(data []byte) error {
+	switch ErrorCode(data) {
+	case ErrorCodeInvalidRequest:
+		*s = ErrorCodeInvalidRequest
+		return nil
+	case ErrorCodeUnknownTimeZone:
+		*s = ErrorCodeUnknownTimeZone
+		return nil
+	case ErrorCodeInvalidCursor:
+		*s = ErrorCodeInvalidCursor
+		return nil
+	case ErrorCodeInvalidRange:
+		*s = ErrorCodeInvalidRange
+		return nil
+	case ErrorCodeEmptyQuery:
+		*s = ErrorCodeEmptyQuery
+		return nil
+	case ErrorCodeSelfFollow:
+		*s = ErrorCodeSelfFollow
+		return nil
+	case ErrorCodeUserNotFound:
+		*s = ErrorCodeUserNotFound
+		return nil
+	case ErrorCodeBookNotFound:
+		*s = ErrorCodeBookNotFound
+		return nil
+	case ErrorCodeNoteNotFound:
+		*s = ErrorCodeNoteNotFound
+		return nil
+	case ErrorCodeHighlightNotFound:
+		*s = ErrorCodeHighlightNotFound
+		return nil
+	case ErrorCodeBookmarkNotFound:
+		*s = ErrorCodeBookmarkNotFound
+		return nil
+	case ErrorCodeAuthorNotFound:
+		*s = ErrorCodeAuthorNotFound
+		return nil
+	case ErrorCodeClubNotFound:
+		*s = ErrorCodeClubNotFound
+		return nil
+	case ErrorCodeMemberNotFound:
+		*s = ErrorCodeMemberNotFound
+		return nil
+	case ErrorCodeShelfNotFound:
+		*s = ErrorCodeShelfNotFound
+		return nil
+	case ErrorCodeBookNotOnShelf:
+		*s = ErrorCodeBookNotOnShelf
+		return nil
+	case ErrorCodeCoverNotFound:
+		*s = ErrorCodeCoverNotFound
+		return nil
+	case ErrorCodeEditionNotFound:
+		*s = ErrorCodeEditionNotFound
+		return nil
+	case ErrorCodePlanNotFound:
+		*s = ErrorCodePlanNotFound
+		return nil
+	case ErrorCodeReviewNotFound:
+		*s = ErrorCodeReviewNotFound
+		return nil
+	case ErrorCodeQueueEmpty:
+		*s = ErrorCodeQueueEmpty
+		return nil
+	case ErrorCodeNotFollowing:
+		*s = ErrorCodeNotFollowing
+		return nil
+	case ErrorCodeBookExists:
+		*s = ErrorCodeBookExists
+		return nil
+	case ErrorCodeAlreadyReading:
+		*s = ErrorCodeAlreadyReading
+		return nil
+	case ErrorCodeBookNotRead:
+		*s = ErrorCodeBookNotRead
+		return nil
+	case ErrorCodeBookRead:
+		*s = ErrorCodeBookRead
+		return nil
+	case ErrorCodeNotInQueue:
+		*s = ErrorCodeNotInQueue
+		return nil
+	case ErrorCodeShelfExists:
+		*s = ErrorCodeShelfExists
+		return nil
+	case ErrorCodeLengthUnknown:
+		*s = ErrorCodeLengthUnknown
+		return nil
+	case ErrorCodeAlreadyMember:
+		*s = ErrorCodeAlreadyMember
+		return nil
+	case ErrorCodeAlreadyInvited:
+		*s = ErrorCodeAlreadyInvited
+		return nil
+	case ErrorCodeNotInvited:
+		*s = ErrorCodeNotInvited
+		return nil
+	case ErrorCodeOwnerCannotLeave:
+		*s = ErrorCodeOwnerCannotLeave
+		return nil
+	case ErrorCodeNoCurrentBook:
+		*s = ErrorCodeNoCurrentBook
+		return nil
+	case ErrorCodeImageTooLarge:
+		*s = ErrorCodeImageTooLarge
+		return nil
+	case ErrorCodeNotFound:
+		*s = ErrorCodeNotFound
+		return nil
+	case ErrorCodeMethodNotAllowed:
+		*s = ErrorCodeMethodNotAllowed
+		return nil
+	case ErrorCodeUnsupportedMediaType:
+		*s = ErrorCodeUnsupportedMediaType
+		return nil
+	case ErrorCodeNotImplemented:
+		*s = ErrorCodeNotImplemented
+		return nil
+	case ErrorCodeInternal:
+		*s = ErrorCodeInternal
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// ErrorStatusCode wraps Error with StatusCode.
+type ErrorStatusCode struct {
+	StatusCode int
+	Response   Error
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *ErrorStatusCode) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetResponse returns the value of Response.
+func (s *ErrorStatusCode) GetResponse() Error {
+	return s.Response
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *ErrorStatusCode) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ErrorStatusCode) SetResponse(val Error) {
+	s.Response = val
+}
+
+type ExportBookHighlightsOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportBookHighlightsOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type ExportReadingPlansOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportReadingPlansOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type ExportUserBooksFormat string
+
+const (
+	ExportUserBooksFormatCsv   ExportUserBooksFormat = "csv"
+	ExportUserBooksFormatJsonl ExportUserBooksFormat = "jsonl"
+)
+
+// AllValues returns all ExportUserBooksFormat values.
+func (ExportUserBooksFormat) AllValues() []ExportUserBooksFormat {
+	return []ExportUserBooksFormat{
+		ExportUserBooksFormatCsv,
+		ExportUserBooksFormatJsonl,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ExportUserBooksFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case ExportUserBooksFormatCsv:
+		return []byte(s), nil
+	case ExportUserBooksFormatJsonl:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ExportUserBooksFormat) UnmarshalText(data []byte) error {
+	switch ExportUserBooksFormat(data) {
+	case ExportUserBooksFormatCsv:
+		*s = ExportUserBooksFormatCsv
+		return nil
+	case ExportUserBooksFormatJsonl:
+		*s = ExportUserBooksFormatJsonl
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ExportUserBooksOKApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportUserBooksOKApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportUserBooksOKApplicationXNdjson) exportUserBooksRes() {}
+
+type ExportUserBooksOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportUserBooksOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportUserBooksOKTextCsv) exportUserBooksRes() {}
+
+// Ref: #/components/schemas/FeedPage
+type FeedPage struct {
+	Items []Activity `json:"items"`
+	// Cursor of the next page, absent on the last page.
+	NextCursor OptString `json:"next_cursor"`
+}
+
+// GetItems returns the value of Items.
+func (s *FeedPage) GetItems() []Activity {
+	return s.Items
+}
+
+// GetNextCursor returns the value of NextCursor.
+func (s *FeedPage) GetNextCursor() OptString {
+	return s.NextCursor
+}
+
+// SetItems sets the value of Items.
+func (s *FeedPage) SetItems(val []Activity) {
+	s.Items = val
+}
+
+// SetNextCursor sets the value of NextCursor.
+func (s *FeedPage) SetNextCursor(val OptString) {
+	s.NextCursor = val
+}
+
+// Error in a single field of the request.
+// Ref: #/components/schemas/FieldError
+type FieldError struct {
+	// Name of the field.
+	Field string `json:"field"`
+	// What is wrong with the field.
+	Message string `json:"message"`
+}
+
+// GetField returns the value of Field.
+func (s *FieldError) GetField() string {
+	return s.Field
+}
+
+// GetMessage returns the value of Message.
+func (s *FieldError) GetMessage() string {
+	return s.Message
+}
+
+// SetField sets the value of Field.
+func (s *FieldError) SetField(val string) {
+	s.Field = val
+}
+
+// SetMessage sets the value of Message.
+func (s *FieldError) SetMessage(val string) {
+	s.Message = val
+}
+
+// FollowUserNoContent is response for FollowUser operation.
+type FollowUserNoContent struct{}
+
+// GetBookCoverNotModified is response for GetBookCover operation.
+type GetBookCoverNotModified struct {
+	CacheControl OptString
+	Etag         OptString
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *GetBookCoverNotModified) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetEtag returns the value of Etag.
+func (s *GetBookCoverNotModified) GetEtag() OptString {
+	return s.Etag
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *GetBookCoverNotModified) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetEtag sets the value of Etag.
+func (s *GetBookCoverNotModified) SetEtag(val OptString) {
+	s.Etag = val
+}
+
+func (*GetBookCoverNotModified) getBookCoverRes() {}
+
+type GetBookCoverOKImageJpeg struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetBookCoverOKImageJpeg) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetBookCoverOKImageJpegHeaders wraps GetBookCoverOKImageJpeg with response headers.
+type GetBookCoverOKImageJpegHeaders struct {
+	CacheControl OptString
+	Etag         OptString
+	Response     GetBookCoverOKImageJpeg
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *GetBookCoverOKImageJpegHeaders) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetEtag returns the value of Etag.
+func (s *GetBookCoverOKImageJpegHeaders) GetEtag() OptString {
+	return s.Etag
+}
+
+// GetResponse returns the value of Response.
+func (s *GetBookCoverOKImageJpegHeaders) GetResponse() GetBookCoverOKImageJpeg {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *GetBookCoverOKImageJpegHeaders) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetEtag sets the value of Etag.
+func (s *GetBookCoverOKImageJpegHeaders) SetEtag(val OptString) {
+	s.Etag = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetBookCoverOKImageJpegHeaders) SetResponse(val GetBookCoverOKImageJpeg) {
+	s.Response = val
+}
+
+func (*GetBookCoverOKImageJpegHeaders) getBookCoverRes() {}
+
+type GetBookCoverOKImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetBookCoverOKImagePNG) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetBookCoverOKImagePNGHeaders wraps GetBookCoverOKImagePNG with response headers.
+type GetBookCoverOKImagePNGHeaders struct {
+	CacheControl OptString
+	Etag         OptString
+	Response     GetBookCoverOKImagePNG
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *GetBookCoverOKImagePNGHeaders) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetEtag returns the value of Etag.
+func (s *GetBookCoverOKImagePNGHeaders) GetEtag() OptString {
+	return s.Etag
+}
+
+// GetResponse returns the value of Response.
+func (s *GetBookCoverOKImagePNGHeaders) GetResponse() GetBookCoverOKImagePNG {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *GetBookCoverOKImagePNGHeaders) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetEtag sets the value of Etag.
+func (s *GetBookCoverOKImagePNGHeaders) SetEtag(val OptString) {
+	s.Etag = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetBookCoverOKImagePNGHeaders) SetResponse(val GetBookCoverOKImagePNG) {
+	s.Response = val
+}
+
+func (*GetBookCoverOKImagePNGHeaders) getBookCoverRes() {}
+
+type GetBookCoverSize string
+
+const (
+	GetBookCoverSizeOriginal  GetBookCoverSize = "original"
+	GetBookCoverSizeThumbnail GetBookCoverSize = "thumbnail"
+)
+
+// AllValues returns all GetBookCoverSize values.
+func (GetBookCoverSize) AllValues() []GetBookCoverSize {
+	return []GetBookCoverSize{
+		GetBookCoverSizeOriginal,
+		GetBookCoverSizeThumbnail,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetBookCoverSize) MarshalText() ([]byte, error) {
+	switch s {
+	case GetBookCoverSizeOriginal:
+		return []byte(s), nil
+	case GetBookCoverSizeThumbnail:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetBookCoverSize) UnmarshalText(data []byte) error {
+	switch GetBookCoverSize(data) {
+	case GetBookCoverSizeOriginal:
+		*s = GetBookCoverSizeOriginal
+		return nil
+	case GetBookCoverSizeThumbnail:
+		*s = GetBookCoverSizeThumbnail
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type GetYearReportOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetYearReportOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// Quoted text of a book.
+// Ref: #/components/schemas/Highlight
+type Highlight struct {
+	// Unique ID of the highlight.
+	ID int `json:"id"`
+	// Highlighted text.
+	Text string `json:"text"`
+	// Page where the highlight starts.
+	PageStart OptInt `json:"page_start"`
+	// Page where the highlight ends.
+	PageEnd OptInt `json:"page_end"`
+	// E-reader location where the highlight starts.
+	LocationStart OptInt `json:"location_start"`
+	// E-reader location where the highlight ends.
+	LocationEnd OptInt         `json:"location_end"`
+	Color       HighlightColor `json:"color"`
+	// When the highlight was made.
+	Added OptDateTime `json:"added"`
+}
+
+// GetID returns the value of ID.
+func (s *Highlight) GetID() int {
+	return s.ID
+}
+
+// GetText returns the value of Text.
+func (s *Highlight) GetText() string {
+	return s.Text
+}
+
+// GetPageStart returns the value of PageStart.
+func (s *Highlight) GetPageStart() OptInt {
+	return s.PageStart
+}
+
+// GetPageEnd returns the value of PageEnd.
+func (s *Highlight) GetPageEnd() OptInt {
+	return s.PageEnd
+}
+
+// GetLocationStart returns the value of LocationStart.
+func (s *Highlight) GetLocationStart() OptInt {
+	return s.LocationStart
+}
+
+// GetLocationEnd returns the value of LocationEnd.
+func (s *Highlight) GetLocationEnd() OptInt {
+	return s.LocationEnd
+}
+
+// GetColor returns the value of Color.
+func (s *Highlight) GetColor() HighlightColor {
+	return s.Color
+}
+
+// GetAdded returns the value of Added.
+func (s *Highlight) GetAdded() OptDateTime {
+	return s.Added
+}
+
+// SetID sets the value of ID.
+func (s *Highlight) SetID(val int) {
+	s.ID = val
+}
+
+// SetText sets the value of Text.
+func (s *Highlight) SetText(val string) {
+	s.Text = val
+}
+
+// SetPageStart sets the value of PageStart.
+func (s *Highlight) SetPageStart(val OptInt) {
+	s.PageStart = val
+}
+
+// SetPageEnd sets the value of PageEnd.
+func (s *Highlight) SetPageEnd(val OptInt) {
+	s.PageEnd = val
+}
+
+// SetLocationStart sets the value of LocationStart.
+func (s *Highlight) SetLocationStart(val OptInt) {
+	s.LocationStart = val
+}
+
+// SetLocationEnd sets the value of LocationEnd.
+func (s *Highlight) SetLocationEnd(val OptInt) {
+	s.LocationEnd = val
+}
+
+// SetColor sets the value of Color.
+func (s *Highlight) SetColor(val HighlightColor) {
+	s.Color = val
+}
+
+// SetAdded sets the value of Added.
+func (s *Highlight) SetAdded(val OptDateTime) {
+	s.Added = val
+}
+
+// Color of the highlight.
+// Ref: #/components/schemas/HighlightColor
+type HighlightColor string
+
+const (
+	HighlightColorYellow HighlightColor = "yellow"
+	HighlightColorGreen  HighlightColor = "green"
+	HighlightColorBlue   HighlightColor = "blue"
+	HighlightColorPink   HighlightColor = "pink"
+	HighlightColorOrange HighlightColor = "orange"
+)
+
+// AllValues returns all HighlightColor values.
+func (HighlightColor) AllValues() []HighlightColor {
+	return []HighlightColor{
+		HighlightColorYellow,
+		HighlightColorGreen,
+		HighlightColorBlue,
+		HighlightColorPink,
+		HighlightColorOrange,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s HighlightColor) MarshalText() ([]byte, error) {
+	switch s {
+	case HighlightColorYellow:
+		return []byte(s), nil
+	case HighlightColorGreen:
+		return []byte(s), nil
+	case HighlightColorBlue:
+		return []byte(s), nil
+	case HighlightColorPink:
+		return []byte(s), nil
+	case HighlightColorOrange:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *HighlightColor) UnmarshalText(data []byte) error {
+	switch HighlightColor(data) {
+	case HighlightColorYellow:
+		*s = HighlightColorYellow
+		return nil
+	case HighlightColorGreen:
+		*s = HighlightColorGreen
+		return nil
+	case HighlightColorBlue:
+		*s = HighlightColorBlue
+		return nil
+	case HighlightColorPink:
+		*s = HighlightColorPink
+		return nil
+	case HighlightColorOrange:
+		*s = HighlightColorOrange
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ImportGoodreadsReq struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportGoodreadsReq) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type ImportKindleClippingsReq struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportKindleClippingsReq) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// Result of the import.
+// Ref: #/components/schemas/ImportReport
+type ImportReport struct {
+	// Whether the shelf was left unchanged.
+	DryRun bool `json:"dry_run"`
+	// Number of new books.
+	Added int `json:"added"`
+	// Number of books replaced by imported ones.
+	Overwritten int `json:"overwritten"`
+	// Number of conflicting books left as they were.
+	Skipped int `json:"skipped"`
+	// Number of imported highlights.
+	Highlights OptInt `json:"highlights"`
+	// Rows that were not imported.
+	Errors []ImportRowError `json:"errors"`
+}
+
+// GetDryRun returns the value of DryRun.
+func (s *ImportReport) GetDryRun() bool {
+	return s.DryRun
+}
+
+// GetAdded returns the value of Added.
+func (s *ImportReport) GetAdded() int {
+	return s.Added
+}
+
+// GetOverwritten returns the value of Overwritten.
+func (s *ImportReport) GetOverwritten() int {
+	return s.Overwritten
+}
+
+// GetSkipped returns the value of Skipped.
+func (s *ImportReport) GetSkipped() int {
+	return s.Skipped
+}
+
+// GetHighlights returns the value of Highlights.
+func (s *ImportReport) GetHighlights() OptInt {
+	return s.Highlights
+}
+
+// GetErrors returns the value of Errors.
+func (s *ImportReport) GetErrors() []ImportRowError {
+	return s.Errors
+}
+
+// SetDryRun sets the value of DryRun.
+func (s *ImportReport) SetDryRun(val bool) {
+	s.DryRun = val
+}
+
+// SetAdded sets the value of Added.
+func (s *ImportReport) SetAdded(val int) {
+	s.Added = val
+}
+
+// SetOverwritten sets the value of Overwritten.
+func (s *ImportReport) SetOverwritten(val int) {
+	s.Overwritten = val
+}
+
+// SetSkipped sets the value of Skipped.
+func (s *ImportReport) SetSkipped(val int) {
+	s.Skipped = val
+}
+
+// SetHighlights sets the value of Highlights.
+func (s *ImportReport) SetHighlights(val OptInt) {
+	s.Highlights = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ImportReport) SetErrors(val []ImportRowError) {
+	s.Errors = val
+}
+
+// Error in a row of the imported file.
+// Ref: #/components/schemas/ImportRowError
+type ImportRowError struct {
+	// Number of the row starting from 1, CSV header is not counted. For Kindle clippings it's the number
+	// of the clipping.
+	Row int `json:"row"`
+	// Error description.
+	Message string `json:"message"`
+}
+
+// GetRow returns the value of Row.
+func (s *ImportRowError) GetRow() int {
+	return s.Row
+}
+
+// GetMessage returns the value of Message.
+func (s *ImportRowError) GetMessage() string {
+	return s.Message
+}
+
+// SetRow sets the value of Row.
+func (s *ImportRowError) SetRow(val int) {
+	s.Row = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ImportRowError) SetMessage(val string) {
+	s.Message = val
+}
+
+type ImportUserBooksReqApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportUserBooksReqApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportUserBooksReqApplicationXNdjson) importUserBooksReq() {}
+
+type ImportUserBooksReqTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportUserBooksReqTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportUserBooksReqTextCsv) importUserBooksReq() {}
+
+// Language of the messages.
+// Ref: #/components/schemas/Language
+type Language string
+
+const (
+	LanguageEn Language = "en"
+	LanguageRu Language = "ru"
+)
+
+// AllValues returns all Language values.
+func (Language) AllValues() []Language {
+	return []Language{
+		LanguageEn,
+		LanguageRu,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Language) MarshalText() ([]byte, error) {
+	switch s {
+	case LanguageEn:
+		return []byte(s), nil
+	case LanguageRu:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Language) UnmarshalText(data []byte) error {
+	switch Language(data) {
+	case LanguageEn:
+		*s = LanguageEn
+		return nil
+	case LanguageRu:
+		*s = LanguageRu
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// LeaveClubNoContent is response for LeaveClub operation.
+type LeaveClubNoContent struct{}
+
+// Ref: #/components/schemas/Locale
+type Locale struct {
+	Language Language `json:"language"`
+}
+
+// GetLanguage returns the value of Language.
+func (s *Locale) GetLanguage() Language {
+	return s.Language
+}
+
+// SetLanguage sets the value of Language.
+func (s *Locale) SetLanguage(val Language) {
+	s.Language = val
+}
+
+// Ref: #/components/schemas/MemberProgress
+type MemberProgress struct {
+	UserID int `json:"user_id"`
+	// Whether the book is on the member's shelf.
+	HasBook bool             `json:"has_book"`
+	Status  OptReadingStatus `json:"status"`
+	Unit    OptProgressUnit  `json:"unit"`
+	// Position in the member's edition.
+	Page OptInt `json:"page"`
+	// Part of the book read, from 0 to 1. Zero if the length of the member's edition is unknown.
+	Progress float64 `json:"progress"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *MemberProgress) GetUserID() int {
+	return s.UserID
+}
+
+// GetHasBook returns the value of HasBook.
+func (s *MemberProgress) GetHasBook() bool {
+	return s.HasBook
+}
+
+// GetStatus returns the value of Status.
+func (s *MemberProgress) GetStatus() OptReadingStatus {
+	return s.Status
+}
+
+// GetUnit returns the value of Unit.
+func (s *MemberProgress) GetUnit() OptProgressUnit {
+	return s.Unit
+}
+
+// GetPage returns the value of Page.
+func (s *MemberProgress) GetPage() OptInt {
+	return s.Page
+}
+
+// GetProgress returns the value of Progress.
+func (s *MemberProgress) GetProgress() float64 {
+	return s.Progress
+}
+
+// SetUserID sets the value of UserID.
+func (s *MemberProgress) SetUserID(val int) {
+	s.UserID = val
+}
+
+// SetHasBook sets the value of HasBook.
+func (s *MemberProgress) SetHasBook(val bool) {
+	s.HasBook = val
+}
+
+// SetStatus sets the value of Status.
+func (s *MemberProgress) SetStatus(val OptReadingStatus) {
+	s.Status = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *MemberProgress) SetUnit(val OptProgressUnit) {
+	s.Unit = val
+}
+
+// SetPage sets the value of Page.
+func (s *MemberProgress) SetPage(val OptInt) {
+	s.Page = val
+}
+
+// SetProgress sets the value of Progress.
+func (s *MemberProgress) SetProgress(val float64) {
+	s.Progress = val
+}
+
+// Ref: #/components/schemas/Milestone
+type Milestone struct {
+	Name    string `json:"name"`
+	Percent int    `json:"percent"`
+	// When the last member got past the milestone.
+	Reached OptDateTime `json:"reached"`
+}
+
+// GetName returns the value of Name.
+func (s *Milestone) GetName() string {
+	return s.Name
+}
+
+// GetPercent returns the value of Percent.
+func (s *Milestone) GetPercent() int {
+	return s.Percent
+}
+
+// GetReached returns the value of Reached.
+func (s *Milestone) GetReached() OptDateTime {
+	return s.Reached
+}
+
+// SetName sets the value of Name.
+func (s *Milestone) SetName(val string) {
+	s.Name = val
+}
+
+// SetPercent sets the value of Percent.
+func (s *Milestone) SetPercent(val int) {
+	s.Percent = val
+}
+
+// SetReached sets the value of Reached.
+func (s *Milestone) SetReached(val OptDateTime) {
+	s.Reached = val
+}
+
+// Ref: #/components/schemas/MonthCount
+type MonthCount struct {
+	Month string `json:"month"`
+	Books int    `json:"books"`
+}
+
+// GetMonth returns the value of Month.
+func (s *MonthCount) GetMonth() string {
+	return s.Month
+}
+
+// GetBooks returns the value of Books.
+func (s *MonthCount) GetBooks() int {
+	return s.Books
+}
+
+// SetMonth sets the value of Month.
+func (s *MonthCount) SetMonth(val string) {
+	s.Month = val
+}
+
+// SetBooks sets the value of Books.
+func (s *MonthCount) SetBooks(val int) {
+	s.Books = val
+}
+
+// Book to add, title and author may be omitted if ISBN is given. Status is reading by default.
+// Ref: #/components/schemas/NewBook
+type NewBook struct {
+	// Uniqie ID of the book from common database.
+	ID int `json:"id"`
+	// Current page user is reading, or position in another unit if the edition being read uses it.
+	Page OptInt `json:"page"`
+	// Title of the book.
+	Title OptString `json:"title"`
+	// Authors of the book. If contributors are not given, they are taken from here, several authors may
+	// be separated with ";", "&", "and" or "и".
+	Author OptString `json:"author"`
+	// Authors, translators and editors of the book, author is made from them if it is not given.
+	Contributors []NewContributor `json:"contributors"`
+	// Publication date.
+	Published OptDate `json:"published"`
+	// Number of pages in the book.
+	Pages OptInt `json:"pages"`
+	// Language of the book as BCP 47 tag.
+	Language OptString `json:"language"`
+	// ISBN-10, hyphens and spaces are allowed.
+	Isbn10 OptString `json:"isbn10"`
+	// ISBN-13, hyphens and spaces are allowed.
+	Isbn13 OptString        `json:"isbn13"`
+	Status OptReadingStatus `json:"status"`
+	// Date the book was read.
+	Finished OptDate   `json:"finished"`
+	Series   OptSeries `json:"series"`
+	// Editions of the book, the user reads one of them.
+	Editions []Edition `json:"editions"`
+	// ID of the edition the user reads, page and read-throughs' pages are in its unit.
+	Edition OptInt `json:"edition"`
+	// Read-throughs of the book, by default made from page, status and finished.
+	Reads []ReadThrough `json:"reads"`
+}
+
+// GetID returns the value of ID.
+func (s *NewBook) GetID() int {
+	return s.ID
+}
+
+// GetPage returns the value of Page.
+func (s *NewBook) GetPage() OptInt {
+	return s.Page
+}
+
+// GetTitle returns the value of Title.
+func (s *NewBook) GetTitle() OptString {
+	return s.Title
+}
+
+// GetAuthor returns the value of Author.
+func (s *NewBook) GetAuthor() OptString {
+	return s.Author
+}
+
+// GetContributors returns the value of Contributors.
+func (s *NewBook) GetContributors() []NewContributor {
+	return s.Contributors
+}
+
+// GetPublished returns the value of Published.
+func (s *NewBook) GetPublished() OptDate {
+	return s.Published
+}
+
+// GetPages returns the value of Pages.
+func (s *NewBook) GetPages() OptInt {
+	return s.Pages
+}
+
+// GetLanguage returns the value of Language.
+func (s *NewBook) GetLanguage() OptString {
+	return s.Language
+}
+
+// GetIsbn10 returns the value of Isbn10.
+func (s *NewBook) GetIsbn10() OptString {
+	return s.Isbn10
+}
+
+// GetIsbn13 returns the value of Isbn13.
+func (s *NewBook) GetIsbn13() OptString {
+	return s.Isbn13
+}
+
+// GetStatus returns the value of Status.
+func (s *NewBook) GetStatus() OptReadingStatus {
+	return s.Status
+}
+
+// GetFinished returns the value of Finished.
+func (s *NewBook) GetFinished() OptDate {
+	return s.Finished
+}
+
+// GetSeries returns the value of Series.
+func (s *NewBook) GetSeries() OptSeries {
+	return s.Series
+}
+
+// GetEditions returns the value of Editions.
+func (s *NewBook) GetEditions() []Edition {
+	return s.Editions
+}
+
+// GetEdition returns the value of Edition.
+func (s *NewBook) GetEdition() OptInt {
+	return s.Edition
+}
+
+// GetReads returns the value of Reads.
+func (s *NewBook) GetReads() []ReadThrough {
+	return s.Reads
+}
+
+// SetID sets the value of ID.
+func (s *NewBook) SetID(val int) {
+	s.ID = val
+}
+
+// SetPage sets the value of Page.
+func (s *NewBook) SetPage(val OptInt) {
+	s.Page = val
+}
+
+// SetTitle sets the value of Title.
+func (s *NewBook) SetTitle(val OptString) {
+	s.Title = val
+}
+
+// SetAuthor sets the value of Author.
+func (s *NewBook) SetAuthor(val OptString) {
+	s.Author = val
+}
+
+// SetContributors sets the value of Contributors.
+func (s *NewBook) SetContributors(val []NewContributor) {
+	s.Contributors = val
+}
+
+// SetPublished sets the value of Published.
+func (s *NewBook) SetPublished(val OptDate) {
+	s.Published = val
+}
+
+// SetPages sets the value of Pages.
+func (s *NewBook) SetPages(val OptInt) {
+	s.Pages = val
+}
+
+// SetLanguage sets the value of Language.
+func (s *NewBook) SetLanguage(val OptString) {
+	s.Language = val
+}
+
+// SetIsbn10 sets the value of Isbn10.
+func (s *NewBook) SetIsbn10(val OptString) {
+	s.Isbn10 = val
+}
+
+// SetIsbn13 sets the value of Isbn13.
+func (s *NewBook) SetIsbn13(val OptString) {
+	s.Isbn13 = val
+}
+
+// SetStatus sets the value of Status.
+func (s *NewBook) SetStatus(val OptReadingStatus) {
+	s.Status = val
+}
+
+// SetFinished sets the value of Finished.
+func (s *NewBook) SetFinished(val OptDate) {
+	s.Finished = val
+}
+
+// SetSeries sets the value of Series.
+func (s *NewBook) SetSeries(val OptSeries) {
+	s.Series = val
+}
+
+// SetEditions sets the value of Editions.
+func (s *NewBook) SetEditions(val []Edition) {
+	s.Editions = val
+}
+
+// SetEdition sets the value of Edition.
+func (s *NewBook) SetEdition(val OptInt) {
+	s.Edition = val
+}
+
+// SetReads sets the value of Reads.
+func (s *NewBook) SetReads(val []ReadThrough) {
+	s.Reads = val
+}
+
+// Bookmark to add or replace an existing one with.
+// Ref: #/components/schemas/NewBookmark
+type NewBookmark struct {
+	// Name of the bookmark.
+	Title OptString `json:"title"`
+	// Page of the bookmark.
+	Page OptInt `json:"page"`
+	// E-reader location of the bookmark.
+	Location OptInt `json:"location"`
+}
+
+// GetTitle returns the value of Title.
+func (s *NewBookmark) GetTitle() OptString {
+	return s.Title
+}
+
+// GetPage returns the value of Page.
+func (s *NewBookmark) GetPage() OptInt {
+	return s.Page
+}
+
+// GetLocation returns the value of Location.
+func (s *NewBookmark) GetLocation() OptInt {
+	return s.Location
+}
+
+// SetTitle sets the value of Title.
+func (s *NewBookmark) SetTitle(val OptString) {
+	s.Title = val
+}
+
+// SetPage sets the value of Page.
+func (s *NewBookmark) SetPage(val OptInt) {
+	s.Page = val
+}
+
+// SetLocation sets the value of Location.
+func (s *NewBookmark) SetLocation(val OptInt) {
+	s.Location = val
+}
+
+// Ref: #/components/schemas/NewClub
+type NewClub struct {
+	Name string `json:"name"`
+	// ID of the user creating the club.
+	Owner int `json:"owner"`
+}
+
+// GetName returns the value of Name.
+func (s *NewClub) GetName() string {
+	return s.Name
+}
+
+// GetOwner returns the value of Owner.
+func (s *NewClub) GetOwner() int {
+	return s.Owner
+}
+
+// SetName sets the value of Name.
+func (s *NewClub) SetName(val string) {
+	s.Name = val
+}
+
+// SetOwner sets the value of Owner.
+func (s *NewClub) SetOwner(val int) {
+	s.Owner = val
+}
+
+// Ref: #/components/schemas/NewContributor
+type NewContributor struct {
+	Name string             `json:"name"`
+	Role OptContributorRole `json:"role"`
+}
+
+// GetName returns the value of Name.
+func (s *NewContributor) GetName() string {
+	return s.Name
+}
+
+// GetRole returns the value of Role.
+func (s *NewContributor) GetRole() OptContributorRole {
+	return s.Role
+}
+
+// SetName sets the value of Name.
+func (s *NewContributor) SetName(val string) {
+	s.Name = val
+}
+
+// SetRole sets the value of Role.
+func (s *NewContributor) SetRole(val OptContributorRole) {
+	s.Role = val
+}
+
+// Ref: #/components/schemas/NewEdition
+type NewEdition struct {
+	Format EditionFormat   `json:"format"`
+	Unit   OptProgressUnit `json:"unit"`
+	// Length of the edition in its unit - pages, locations or seconds. Not needed for percent.
+	Length OptInt `json:"length"`
+	// ISBN-13 of the edition, hyphens and spaces are allowed.
+	Isbn13 OptString `json:"isbn13"`
+}
+
+// GetFormat returns the value of Format.
+func (s *NewEdition) GetFormat() EditionFormat {
+	return s.Format
+}
+
+// GetUnit returns the value of Unit.
+func (s *NewEdition) GetUnit() OptProgressUnit {
+	return s.Unit
+}
+
+// GetLength returns the value of Length.
+func (s *NewEdition) GetLength() OptInt {
+	return s.Length
+}
+
+// GetIsbn13 returns the value of Isbn13.
+func (s *NewEdition) GetIsbn13() OptString {
+	return s.Isbn13
+}
+
+// SetFormat sets the value of Format.
+func (s *NewEdition) SetFormat(val EditionFormat) {
+	s.Format = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *NewEdition) SetUnit(val OptProgressUnit) {
+	s.Unit = val
+}
+
+// SetLength sets the value of Length.
+func (s *NewEdition) SetLength(val OptInt) {
+	s.Length = val
+}
+
+// SetIsbn13 sets the value of Isbn13.
+func (s *NewEdition) SetIsbn13(val OptString) {
+	s.Isbn13 = val
+}
+
+// Highlight to add or replace an existing one with.
+// Ref: #/components/schemas/NewHighlight
+type NewHighlight struct {
+	// Highlighted text.
+	Text string `json:"text"`
+	// Page where the highlight starts.
+	PageStart OptInt `json:"page_start"`
+	// Page where the highlight ends, the start page by default.
+	PageEnd OptInt `json:"page_end"`
+	// E-reader location where the highlight starts.
+	LocationStart OptInt `json:"location_start"`
+	// E-reader location where the highlight ends, the start location by default.
+	LocationEnd OptInt            `json:"location_end"`
+	Color       OptHighlightColor `json:"color"`
+}
+
+// GetText returns the value of Text.
+func (s *NewHighlight) GetText() string {
+	return s.Text
+}
+
+// GetPageStart returns the value of PageStart.
+func (s *NewHighlight) GetPageStart() OptInt {
+	return s.PageStart
+}
+
+// GetPageEnd returns the value of PageEnd.
+func (s *NewHighlight) GetPageEnd() OptInt {
+	return s.PageEnd
+}
+
+// GetLocationStart returns the value of LocationStart.
+func (s *NewHighlight) GetLocationStart() OptInt {
+	return s.LocationStart
+}
+
+// GetLocationEnd returns the value of LocationEnd.
+func (s *NewHighlight) GetLocationEnd() OptInt {
+	return s.LocationEnd
+}
+
+// GetColor returns the value of Color.
+func (s *NewHighlight) GetColor() OptHighlightColor {
+	return s.Color
+}
+
+// SetText sets the value of Text.
+func (s *NewHighlight) SetText(val string) {
+	s.Text = val
+}
+
+// SetPageStart sets the value of PageStart.
+func (s *NewHighlight) SetPageStart(val OptInt) {
+	s.PageStart = val
+}
+
+// SetPageEnd sets the value of PageEnd.
+func (s *NewHighlight) SetPageEnd(val OptInt) {
+	s.PageEnd = val
+}
+
+// SetLocationStart sets the value of LocationStart.
+func (s *NewHighlight) SetLocationStart(val OptInt) {
+	s.LocationStart = val
+}
+
+// SetLocationEnd sets the value of LocationEnd.
+func (s *NewHighlight) SetLocationEnd(val OptInt) {
+	s.LocationEnd = val
+}
+
+// SetColor sets the value of Color.
+func (s *NewHighlight) SetColor(val OptHighlightColor) {
+	s.Color = val
+}
+
+// Ref: #/components/schemas/NewMilestone
+type NewMilestone struct {
+	Name string `json:"name"`
+	// Position of the milestone in the book.
+	Percent int `json:"percent"`
+}
+
+// GetName returns the value of Name.
+func (s *NewMilestone) GetName() string {
+	return s.Name
+}
+
+// GetPercent returns the value of Percent.
+func (s *NewMilestone) GetPercent() int {
+	return s.Percent
+}
+
+// SetName sets the value of Name.
+func (s *NewMilestone) SetName(val string) {
+	s.Name = val
+}
+
+// SetPercent sets the value of Percent.
+func (s *NewMilestone) SetPercent(val int) {
+	s.Percent = val
+}
+
+// Note to add or replace an existing one with.
+// Ref: #/components/schemas/NewNote
+type NewNote struct {
+	// Text of the note.
+	Text string `json:"text"`
+	// Page the note refers to.
+	Page OptInt `json:"page"`
+}
+
+// GetText returns the value of Text.
+func (s *NewNote) GetText() string {
+	return s.Text
+}
+
+// GetPage returns the value of Page.
+func (s *NewNote) GetPage() OptInt {
+	return s.Page
+}
+
+// SetText sets the value of Text.
+func (s *NewNote) SetText(val string) {
+	s.Text = val
+}
+
+// SetPage sets the value of Page.
+func (s *NewNote) SetPage(val OptInt) {
+	s.Page = val
+}
+
+// Ref: #/components/schemas/NewReadingPlan
+type NewReadingPlan struct {
+	// Day the book should be finished by, inclusive.
+	Deadline time.Time `json:"deadline"`
+	// Days of the week without reading.
+	RestDays []Weekday `json:"rest_days"`
+	// IANA time zone the days are counted in.
+	TimeZone OptString `json:"time_zone"`
+}
+
+// GetDeadline returns the value of Deadline.
+func (s *NewReadingPlan) GetDeadline() time.Time {
+	return s.Deadline
+}
+
+// GetRestDays returns the value of RestDays.
+func (s *NewReadingPlan) GetRestDays() []Weekday {
+	return s.RestDays
+}
+
+// GetTimeZone returns the value of TimeZone.
+func (s *NewReadingPlan) GetTimeZone() OptString {
+	return s.TimeZone
+}
+
+// SetDeadline sets the value of Deadline.
+func (s *NewReadingPlan) SetDeadline(val time.Time) {
+	s.Deadline = val
+}
+
+// SetRestDays sets the value of RestDays.
+func (s *NewReadingPlan) SetRestDays(val []Weekday) {
+	s.RestDays = val
+}
+
+// SetTimeZone sets the value of TimeZone.
+func (s *NewReadingPlan) SetTimeZone(val OptString) {
+	s.TimeZone = val
+}
+
+// Review to add or replace an existing one with.
+// Ref: #/components/schemas/NewReview
+type NewReview struct {
+	Rating Rating `json:"rating"`
+	// Text of the review, may be omitted to only rate the book.
+	Text       OptString           `json:"text"`
+	Visibility OptReviewVisibility `json:"visibility"`
+}
+
+// GetRating returns the value of Rating.
+func (s *NewReview) GetRating() Rating {
+	return s.Rating
+}
+
+// GetText returns the value of Text.
+func (s *NewReview) GetText() OptString {
+	return s.Text
+}
+
+// GetVisibility returns the value of Visibility.
+func (s *NewReview) GetVisibility() OptReviewVisibility {
+	return s.Visibility
+}
+
+// SetRating sets the value of Rating.
+func (s *NewReview) SetRating(val Rating) {
+	s.Rating = val
+}
+
+// SetText sets the value of Text.
+func (s *NewReview) SetText(val OptString) {
+	s.Text = val
+}
+
+// SetVisibility sets the value of Visibility.
+func (s *NewReview) SetVisibility(val OptReviewVisibility) {
+	s.Visibility = val
+}
+
+// Ref: #/components/schemas/NewShelf
+type NewShelf struct {
+	// Name of the shelf, unique for the user ignoring case.
+	Name string `json:"name"`
+}
+
+// GetName returns the value of Name.
+func (s *NewShelf) GetName() string {
+	return s.Name
+}
+
+// SetName sets the value of Name.
+func (s *NewShelf) SetName(val string) {
+	s.Name = val
+}
+
+// User's note to the book.
+// Ref: #/components/schemas/Note
+type Note struct {
+	// Unique ID of the note.
+	ID int `json:"id"`
+	// Text of the note.
+	Text string `json:"text"`
+	// Page the note refers to.
+	Page OptInt `json:"page"`
+	// When the note was made.
+	Created time.Time `json:"created"`
+}
+
+// GetID returns the value of ID.
+func (s *Note) GetID() int {
+	return s.ID
+}
+
+// GetText returns the value of Text.
+func (s *Note) GetText() string {
+	return s.Text
+}
+
+// GetPage returns the value of Page.
+func (s *Note) GetPage() OptInt {
+	return s.Page
+}
+
+// GetCreated returns the value of Created.
+func (s *Note) GetCreated() time.Time {
+	return s.Created
+}
+
+// SetID sets the value of ID.
+func (s *Note) SetID(val int) {
+	s.ID = val
+}
+
+// SetText sets the value of Text.
+func (s *Note) SetText(val string) {
+	s.Text = val
+}
+
+// SetPage sets the value of Page.
+func (s *Note) SetPage(val OptInt) {
+	s.Page = val
+}
+
+// SetCreated sets the value of Created.
+func (s *Note) SetCreated(val time.Time) {
+	s.Created = val
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptConflictPolicy returns new OptConflictPolicy with value set to v.
+func NewOptConflictPolicy(v ConflictPolicy) OptConflictPolicy {
+	return OptConflictPolicy{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptConflictPolicy is optional ConflictPolicy.
+type OptConflictPolicy struct {
+	Value ConflictPolicy
+	Set   bool
+}
+
+// IsSet returns true if OptConflictPolicy was set.
+func (o OptConflictPolicy) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptConflictPolicy) Reset() {
+	var v ConflictPolicy
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptConflictPolicy) SetTo(v ConflictPolicy) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptConflictPolicy) Get() (v ConflictPolicy, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptConflictPolicy) Or(d ConflictPolicy) ConflictPolicy {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptContributorRole returns new OptContributorRole with value set to v.
+func NewOptContributorRole(v ContributorRole) OptContributorRole {
+	return OptContributorRole{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptContributorRole is optional ContributorRole.
+type OptContributorRole struct {
+	Value ContributorRole
+	Set   bool
+}
+
+// IsSet returns true if OptContributorRole was set.
+func (o OptContributorRole) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptContributorRole) Reset() {
+	var v ContributorRole
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptContributorRole) SetTo(v ContributorRole) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptContributorRole) Get() (v ContributorRole, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptContributorRole) Or(d ContributorRole) ContributorRole {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptExportUserBooksFormat returns new OptExportUserBooksFormat with value set to v.
+func NewOptExportUserBooksFormat(v ExportUserBooksFormat) OptExportUserBooksFormat {
+	return OptExportUserBooksFormat{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptExportUserBooksFormat is optional ExportUserBooksFormat.
+type OptExportUserBooksFormat struct {
+	Value ExportUserBooksFormat
+	Set   bool
+}
+
+// IsSet returns true if OptExportUserBooksFormat was set.
+func (o OptExportUserBooksFormat) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptExportUserBooksFormat) Reset() {
+	var v ExportUserBooksFormat
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptExportUserBooksFormat) SetTo(v ExportUserBooksFormat) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptExportUserBooksFormat) Get() (v ExportUserBooksFormat, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptExportUserBooksFormat) Or(d ExportUserBooksFormat) ExportUserBooksFormat {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptGetBookCoverSize returns new OptGetBookCoverSize with value set to v.
+func NewOptGetBookCoverSize(v GetBookCoverSize) OptGetBookCoverSize {
+	return OptGetBookCoverSize{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptGetBookCoverSize is optional GetBookCoverSize.
+type OptGetBookCoverSize struct {
+	Value GetBookCoverSize
+	Set   bool
+}
+
+// IsSet returns true if OptGetBookCoverSize was set.
+func (o OptGetBookCoverSize) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGetBookCoverSize) Reset() {
+	var v GetBookCoverSize
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptGetBookCoverSize) SetTo(v GetBookCoverSize) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetBookCoverSize) Get() (v GetBookCoverSize, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptGetBookCoverSize) Or(d GetBookCoverSize) GetBookCoverSize {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptHighlightColor returns new OptHighlightColor with value set to v.
+func NewOptHighlightColor(v HighlightColor) OptHighlightColor {
+	return OptHighlightColor{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptHighlightColor is optional HighlightColor.
+type OptHighlightColor struct {
+	Value HighlightColor
+	Set   bool
+}
+
+// IsSet returns true if OptHighlightColor was set.
+func (o OptHighlightColor) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptHighlightColor) Reset() {
+	var v HighlightColor
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptHighlightColor) SetTo(v HighlightColor) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptHighlightColor) Get() (v HighlightColor, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptHighlightColor) Or(d HighlightColor) HighlightColor {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptProgressUnit returns new OptProgressUnit with value set to v.
+func NewOptProgressUnit(v ProgressUnit) OptProgressUnit {
+	return OptProgressUnit{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptProgressUnit is optional ProgressUnit.
+type OptProgressUnit struct {
+	Value ProgressUnit
+	Set   bool
+}
+
+// IsSet returns true if OptProgressUnit was set.
+func (o OptProgressUnit) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptProgressUnit) Reset() {
+	var v ProgressUnit
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptProgressUnit) SetTo(v ProgressUnit) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptProgressUnit) Get() (v ProgressUnit, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptProgressUnit) Or(d ProgressUnit) ProgressUnit {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRating returns new OptRating with value set to v.
+func NewOptRating(v Rating) OptRating {
+	return OptRating{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRating is optional Rating.
+type OptRating struct {
+	Value Rating
+	Set   bool
+}
+
+// IsSet returns true if OptRating was set.
+func (o OptRating) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRating) Reset() {
+	var v Rating
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRating) SetTo(v Rating) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRating) Get() (v Rating, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRating) Or(d Rating) Rating {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRatingSummary returns new OptRatingSummary with value set to v.
+func NewOptRatingSummary(v RatingSummary) OptRatingSummary {
+	return OptRatingSummary{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRatingSummary is optional RatingSummary.
+type OptRatingSummary struct {
+	Value RatingSummary
+	Set   bool
+}
+
+// IsSet returns true if OptRatingSummary was set.
+func (o OptRatingSummary) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRatingSummary) Reset() {
+	var v RatingSummary
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRatingSummary) SetTo(v RatingSummary) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRatingSummary) Get() (v RatingSummary, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRatingSummary) Or(d RatingSummary) RatingSummary {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptReadingStatus returns new OptReadingStatus with value set to v.
+func NewOptReadingStatus(v ReadingStatus) OptReadingStatus {
+	return OptReadingStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptReadingStatus is optional ReadingStatus.
+type OptReadingStatus struct {
+	Value ReadingStatus
+	Set   bool
+}
+
+// IsSet returns true if OptReadingStatus was set.
+func (o OptReadingStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptReadingStatus) Reset() {
+	var v ReadingStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptReadingStatus) SetTo(v ReadingStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptReadingStatus) Get() (v ReadingStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptReadingStatus) Or(d ReadingStatus) ReadingStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptReviewVisibility returns new OptReviewVisibility with value set to v.
+func NewOptReviewVisibility(v ReviewVisibility) OptReviewVisibility {
+	return OptReviewVisibility{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptReviewVisibility is optional ReviewVisibility.
+type OptReviewVisibility struct {
+	Value ReviewVisibility
+	Set   bool
+}
+
+// IsSet returns true if OptReviewVisibility was set.
+func (o OptReviewVisibility) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptReviewVisibility) Reset() {
+	var v ReviewVisibility
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptReviewVisibility) SetTo(v ReviewVisibility) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptReviewVisibility) Get() (v ReviewVisibility, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptReviewVisibility) Or(d ReviewVisibility) ReviewVisibility {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSeries returns new OptSeries with value set to v.
+func NewOptSeries(v Series) OptSeries {
+	return OptSeries{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSeries is optional Series.
+type OptSeries struct {
+	Value Series
+	Set   bool
+}
+
+// IsSet returns true if OptSeries was set.
+func (o OptSeries) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSeries) Reset() {
+	var v Series
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSeries) SetTo(v Series) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSeries) Get() (v Series, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSeries) Or(d Series) Series {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSimilarityMetric returns new OptSimilarityMetric with value set to v.
+func NewOptSimilarityMetric(v SimilarityMetric) OptSimilarityMetric {
+	return OptSimilarityMetric{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSimilarityMetric is optional SimilarityMetric.
+type OptSimilarityMetric struct {
+	Value SimilarityMetric
+	Set   bool
+}
+
+// IsSet returns true if OptSimilarityMetric was set.
+func (o OptSimilarityMetric) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSimilarityMetric) Reset() {
+	var v SimilarityMetric
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSimilarityMetric) SetTo(v SimilarityMetric) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSimilarityMetric) Get() (v SimilarityMetric, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSimilarityMetric) Or(d SimilarityMetric) SimilarityMetric {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/PlanDay
+type PlanDay struct {
+	Date time.Time `json:"date"`
+	// Position to start the day from, in the unit of the plan.
+	From int `json:"from"`
+	// Position to reach by the end of the day.
+	To int `json:"to"`
+	// Pages, or other units, to read in the day.
+	Amount int `json:"amount"`
+	// Whether the part of the day is read already.
+	Done bool `json:"done"`
+}
+
+// GetDate returns the value of Date.
+func (s *PlanDay) GetDate() time.Time {
+	return s.Date
+}
+
+// GetFrom returns the value of From.
+func (s *PlanDay) GetFrom() int {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *PlanDay) GetTo() int {
+	return s.To
+}
+
+// GetAmount returns the value of Amount.
+func (s *PlanDay) GetAmount() int {
+	return s.Amount
+}
+
+// GetDone returns the value of Done.
+func (s *PlanDay) GetDone() bool {
+	return s.Done
+}
+
+// SetDate sets the value of Date.
+func (s *PlanDay) SetDate(val time.Time) {
+	s.Date = val
+}
+
+// SetFrom sets the value of From.
+func (s *PlanDay) SetFrom(val int) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *PlanDay) SetTo(val int) {
+	s.To = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *PlanDay) SetAmount(val int) {
+	s.Amount = val
+}
+
+// SetDone sets the value of Done.
+func (s *PlanDay) SetDone(val bool) {
+	s.Done = val
+}
+
+// Ref: #/components/schemas/Privacy
+type Privacy struct {
+	Activity ActivityVisibility `json:"activity"`
+}
+
+// GetActivity returns the value of Activity.
+func (s *Privacy) GetActivity() ActivityVisibility {
+	return s.Activity
+}
+
+// SetActivity sets the value of Activity.
+func (s *Privacy) SetActivity(val ActivityVisibility) {
+	s.Activity = val
+}
+
+// Unit of reading progress. Audiobooks are measured in seconds, other editions in pages by default.
+// Locations of e-readers are used for reflowable ebooks.
+// Ref: #/components/schemas/ProgressUnit
+type ProgressUnit string
+
+const (
+	ProgressUnitPage     ProgressUnit = "page"
+	ProgressUnitLocation ProgressUnit = "location"
+	ProgressUnitPercent  ProgressUnit = "percent"
+	ProgressUnitSeconds  ProgressUnit = "seconds"
+)
+
+// AllValues returns all ProgressUnit values.
+func (ProgressUnit) AllValues() []ProgressUnit {
+	return []ProgressUnit{
+		ProgressUnitPage,
+		ProgressUnitLocation,
+		ProgressUnitPercent,
+		ProgressUnitSeconds,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ProgressUnit) MarshalText() ([]byte, error) {
+	switch s {
+	case ProgressUnitPage:
+		return []byte(s), nil
+	case ProgressUnitLocation:
+		return []byte(s), nil
+	case ProgressUnitPercent:
+		return []byte(s), nil
+	case ProgressUnitSeconds:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ProgressUnit) UnmarshalText(data []byte) error {
+	switch ProgressUnit(data) {
+	case ProgressUnitPage:
+		*s = ProgressUnitPage
+		return nil
+	case ProgressUnitLocation:
+		*s = ProgressUnitLocation
+		return nil
+	case ProgressUnitPercent:
+		*s = ProgressUnitPercent
+		return nil
+	case ProgressUnitSeconds:
+		*s = ProgressUnitSeconds
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type PutBookCoverReqImageJpeg struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PutBookCoverReqImageJpeg) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PutBookCoverReqImageJpeg) putBookCoverReq() {}
+
+type PutBookCoverReqImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PutBookCoverReqImagePNG) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PutBookCoverReqImagePNG) putBookCoverReq() {}
+
+// Book in the queue of books to read next.
+// Ref: #/components/schemas/QueueItem
+type QueueItem struct {
+	// Position in the queue starting from 1.
+	Position int  `json:"position"`
+	Book     Book `json:"book"`
+}
+
+// GetPosition returns the value of Position.
+func (s *QueueItem) GetPosition() int {
+	return s.Position
+}
+
+// GetBook returns the value of Book.
+func (s *QueueItem) GetBook() Book {
+	return s.Book
+}
+
+// SetPosition sets the value of Position.
+func (s *QueueItem) SetPosition(val int) {
+	s.Position = val
+}
+
+// SetBook sets the value of Book.
+func (s *QueueItem) SetBook(val Book) {
+	s.Book = val
+}
+
+// Ref: #/components/schemas/QueueMove
+type QueueMove struct {
+	// New position in the queue starting from 1, books past the end are moved to the end.
+	Position int `json:"position"`
+}
+
+// GetPosition returns the value of Position.
+func (s *QueueMove) GetPosition() int {
+	return s.Position
+}
+
+// SetPosition sets the value of Position.
+func (s *QueueMove) SetPosition(val int) {
+	s.Position = val
+}
+
+type Rating float64
+
+// Ref: #/components/schemas/RatingCount
+type RatingCount struct {
+	Rating Rating `json:"rating"`
+	Count  int    `json:"count"`
+}
+
+// GetRating returns the value of Rating.
+func (s *RatingCount) GetRating() Rating {
+	return s.Rating
+}
+
+// GetCount returns the value of Count.
+func (s *RatingCount) GetCount() int {
+	return s.Count
+}
+
+// SetRating sets the value of Rating.
+func (s *RatingCount) SetRating(val Rating) {
+	s.Rating = val
+}
+
+// SetCount sets the value of Count.
+func (s *RatingCount) SetCount(val int) {
+	s.Count = val
+}
+
+// Rating of the book across all users.
+// Ref: #/components/schemas/RatingSummary
+type RatingSummary struct {
+	// Average rating, 0 if the book is not rated.
+	Average float64 `json:"average"`
+	// Number of ratings.
+	Count int `json:"count"`
+	// Number of ratings for each value from 1 to 5 stars.
+	Histogram []RatingCount `json:"histogram"`
+}
+
+// GetAverage returns the value of Average.
+func (s *RatingSummary) GetAverage() float64 {
+	return s.Average
+}
+
+// GetCount returns the value of Count.
+func (s *RatingSummary) GetCount() int {
+	return s.Count
+}
+
+// GetHistogram returns the value of Histogram.
+func (s *RatingSummary) GetHistogram() []RatingCount {
+	return s.Histogram
+}
+
+// SetAverage sets the value of Average.
+func (s *RatingSummary) SetAverage(val float64) {
+	s.Average = val
+}
+
+// SetCount sets the value of Count.
+func (s *RatingSummary) SetCount(val int) {
+	s.Count = val
+}
+
+// SetHistogram sets the value of Histogram.
+func (s *RatingSummary) SetHistogram(val []RatingCount) {
+	s.Histogram = val
+}
+
+// One reading of the book from start to finish.
+// Ref: #/components/schemas/ReadThrough
+type ReadThrough struct {
+	// When the reading was started, may be unknown for imported books.
+	Started OptDate `json:"started"`
+	// When the book was read, may be unknown for imported books.
+	Finished OptDate `json:"finished"`
+	// Current page of this read-through, or position in the unit of the edition.
+	Page int `json:"page"`
+	// Whether the book was read to the end.
+	Completed bool `json:"completed"`
+}
+
+// GetStarted returns the value of Started.
+func (s *ReadThrough) GetStarted() OptDate {
+	return s.Started
+}
+
+// GetFinished returns the value of Finished.
+func (s *ReadThrough) GetFinished() OptDate {
+	return s.Finished
+}
+
+// GetPage returns the value of Page.
+func (s *ReadThrough) GetPage() int {
+	return s.Page
+}
+
+// GetCompleted returns the value of Completed.
+func (s *ReadThrough) GetCompleted() bool {
+	return s.Completed
+}
+
+// SetStarted sets the value of Started.
+func (s *ReadThrough) SetStarted(val OptDate) {
+	s.Started = val
+}
+
+// SetFinished sets the value of Finished.
+func (s *ReadThrough) SetFinished(val OptDate) {
+	s.Finished = val
+}
+
+// SetPage sets the value of Page.
+func (s *ReadThrough) SetPage(val int) {
+	s.Page = val
+}
+
+// SetCompleted sets the value of Completed.
+func (s *ReadThrough) SetCompleted(val bool) {
+	s.Completed = val
+}
+
+// Plan to finish the book by the deadline.
+// Ref: #/components/schemas/ReadingPlan
+type ReadingPlan struct {
+	BookID   int          `json:"book_id"`
+	Deadline time.Time    `json:"deadline"`
+	RestDays []Weekday    `json:"rest_days"`
+	TimeZone string       `json:"time_zone"`
+	Unit     ProgressUnit `json:"unit"`
+	// Reading days from today to the deadline.
+	Days []PlanDay `json:"days"`
+}
+
+// GetBookID returns the value of BookID.
+func (s *ReadingPlan) GetBookID() int {
+	return s.BookID
+}
+
+// GetDeadline returns the value of Deadline.
+func (s *ReadingPlan) GetDeadline() time.Time {
+	return s.Deadline
+}
+
+// GetRestDays returns the value of RestDays.
+func (s *ReadingPlan) GetRestDays() []Weekday {
+	return s.RestDays
+}
+
+// GetTimeZone returns the value of TimeZone.
+func (s *ReadingPlan) GetTimeZone() string {
+	return s.TimeZone
+}
+
+// GetUnit returns the value of Unit.
+func (s *ReadingPlan) GetUnit() ProgressUnit {
+	return s.Unit
+}
+
+// GetDays returns the value of Days.
+func (s *ReadingPlan) GetDays() []PlanDay {
+	return s.Days
+}
+
+// SetBookID sets the value of BookID.
+func (s *ReadingPlan) SetBookID(val int) {
+	s.BookID = val
+}
+
+// SetDeadline sets the value of Deadline.
+func (s *ReadingPlan) SetDeadline(val time.Time) {
+	s.Deadline = val
+}
+
+// SetRestDays sets the value of RestDays.
+func (s *ReadingPlan) SetRestDays(val []Weekday) {
+	s.RestDays = val
+}
+
+// SetTimeZone sets the value of TimeZone.
+func (s *ReadingPlan) SetTimeZone(val string) {
+	s.TimeZone = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *ReadingPlan) SetUnit(val ProgressUnit) {
+	s.Unit = val
+}
+
+// SetDays sets the value of Days.
+func (s *ReadingPlan) SetDays(val []PlanDay) {
+	s.Days = val
+}
+
+// Statistics of user's reading in the date range.
+// Ref: #/components/schemas/ReadingStats
+type ReadingStats struct {
+	// First day of the range, absent if the range is not limited.
+	From OptDate `json:"from"`
+	// Last day of the range.
+	To       time.Time `json:"to"`
+	TimeZone string    `json:"time_zone"`
+	// Books finished in the months of the range, months without finished books are omitted.
+	FinishedByMonth []MonthCount `json:"finished_by_month"`
+	FinishedByYear  []YearCount  `json:"finished_by_year"`
+	// Pages read in the days of the range, days without reading are omitted.
+	PagesByDay []DayPages `json:"pages_by_day"`
+	// Average pages read in a day the user was reading.
+	PagesPerDay float64 `json:"pages_per_day"`
+	// Average days from start to finish of a book, absent if no finished book has both dates.
+	DaysPerBook OptFloat64 `json:"days_per_book"`
+	// Average pages in finished books, absent if lengths are unknown.
+	AverageLength OptFloat64 `json:"average_length"`
+	// Authors with the most finished books.
+	TopAuthors  []AuthorCount `json:"top_authors"`
+	CurrentYear YearTotals    `json:"current_year"`
+}
+
+// GetFrom returns the value of From.
+func (s *ReadingStats) GetFrom() OptDate {
+	return s.From
+}
+
+// GetTo returns the value of To.
+func (s *ReadingStats) GetTo() time.Time {
+	return s.To
+}
+
+// GetTimeZone returns the value of TimeZone.
+func (s *ReadingStats) GetTimeZone() string {
+	return s.TimeZone
+}
+
+// GetFinishedByMonth returns the value of FinishedByMonth.
+func (s *ReadingStats) GetFinishedByMonth() []MonthCount {
+	return s.FinishedByMonth
+}
+
+// GetFinishedByYear returns the value of FinishedByYear.
+func (s *ReadingStats) GetFinishedByYear() []YearCount {
+	return s.FinishedByYear
+}
+
+// GetPagesByDay returns the value of PagesByDay.
+func (s *ReadingStats) GetPagesByDay() []DayPages {
+	return s.PagesByDay
+}
+
+// GetPagesPerDay returns the value of PagesPerDay.
+func (s *ReadingStats) GetPagesPerDay() float64 {
+	return s.PagesPerDay
+}
+
+// GetDaysPerBook returns the value of DaysPerBook.
+func (s *ReadingStats) GetDaysPerBook() OptFloat64 {
+	return s.DaysPerBook
+}
+
+// GetAverageLength returns the value of AverageLength.
+func (s *ReadingStats) GetAverageLength() OptFloat64 {
+	return s.AverageLength
+}
+
+// GetTopAuthors returns the value of TopAuthors.
+func (s *ReadingStats) GetTopAuthors() []AuthorCount {
+	return s.TopAuthors
+}
+
+// GetCurrentYear returns the value of CurrentYear.
+func (s *ReadingStats) GetCurrentYear() YearTotals {
+	return s.CurrentYear
+}
+
+// SetFrom sets the value of From.
+func (s *ReadingStats) SetFrom(val OptDate) {
+	s.From = val
+}
+
+// SetTo sets the value of To.
+func (s *ReadingStats) SetTo(val time.Time) {
+	s.To = val
+}
+
+// SetTimeZone sets the value of TimeZone.
+func (s *ReadingStats) SetTimeZone(val string) {
+	s.TimeZone = val
+}
+
+// SetFinishedByMonth sets the value of FinishedByMonth.
+func (s *ReadingStats) SetFinishedByMonth(val []MonthCount) {
+	s.FinishedByMonth = val
+}
+
+// SetFinishedByYear sets the value of FinishedByYear.
+func (s *ReadingStats) SetFinishedByYear(val []YearCount) {
+	s.FinishedByYear = val
+}
+
+// SetPagesByDay sets the value of PagesByDay.
+func (s *ReadingStats) SetPagesByDay(val []DayPages) {
+	s.PagesByDay = val
+}
+
+// SetPagesPerDay sets the value of PagesPerDay.
+func (s *ReadingStats) SetPagesPerDay(val float64) {
+	s.PagesPerDay = val
+}
+
+// SetDaysPerBook sets the value of DaysPerBook.
+func (s *ReadingStats) SetDaysPerBook(val OptFloat64) {
+	s.DaysPerBook = val
+}
+
+// SetAverageLength sets the value of AverageLength.
+func (s *ReadingStats) SetAverageLength(val OptFloat64) {
+	s.AverageLength = val
+}
+
+// SetTopAuthors sets the value of TopAuthors.
+func (s *ReadingStats) SetTopAuthors(val []AuthorCount) {
+	s.TopAuthors = val
+}
+
+// SetCurrentYear sets the value of CurrentYear.
+func (s *ReadingStats) SetCurrentYear(val YearTotals) {
+	s.CurrentYear = val
+}
+
+// Whether the user wants to read, is reading or has read the book.
+// Ref: #/components/schemas/ReadingStatus
+type ReadingStatus string
+
+const (
+	ReadingStatusWantToRead ReadingStatus = "want_to_read"
+	ReadingStatusReading    ReadingStatus = "reading"
+	ReadingStatusRead       ReadingStatus = "read"
+)
+
+// AllValues returns all ReadingStatus values.
+func (ReadingStatus) AllValues() []ReadingStatus {
+	return []ReadingStatus{
+		ReadingStatusWantToRead,
+		ReadingStatusReading,
+		ReadingStatusRead,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ReadingStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case ReadingStatusWantToRead:
+		return []byte(s), nil
+	case ReadingStatusReading:
+		return []byte(s), nil
+	case ReadingStatusRead:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ReadingStatus) UnmarshalText(data []byte) error {
+	switch ReadingStatus(data) {
+	case ReadingStatusWantToRead:
+		*s = ReadingStatusWantToRead
+		return nil
+	case ReadingStatusReading:
+		*s = ReadingStatusReading
+		return nil
+	case ReadingStatusRead:
+		*s = ReadingStatusRead
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/Recommendation
+type Recommendation struct {
+	BookID int    `json:"book_id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	// Sum of similarities to user's books.
+	Score float64 `json:"score"`
+	// User's books most similar to the recommended one.
+	Because []int `json:"because"`
+}
+
+// GetBookID returns the value of BookID.
+func (s *Recommendation) GetBookID() int {
+	return s.BookID
+}
+
+// GetTitle returns the value of Title.
+func (s *Recommendation) GetTitle() string {
+	return s.Title
+}
+
+// GetAuthor returns the value of Author.
+func (s *Recommendation) GetAuthor() string {
+	return s.Author
+}
+
+// GetScore returns the value of Score.
+func (s *Recommendation) GetScore() float64 {
+	return s.Score
+}
+
+// GetBecause returns the value of Because.
+func (s *Recommendation) GetBecause() []int {
+	return s.Because
+}
+
+// SetBookID sets the value of BookID.
+func (s *Recommendation) SetBookID(val int) {
+	s.BookID = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Recommendation) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetAuthor sets the value of Author.
+func (s *Recommendation) SetAuthor(val string) {
+	s.Author = val
+}
+
+// SetScore sets the value of Score.
+func (s *Recommendation) SetScore(val float64) {
+	s.Score = val
+}
+
+// SetBecause sets the value of Because.
+func (s *Recommendation) SetBecause(val []int) {
+	s.Because = val
+}
+
+// RemoveBookBookmarkNoContent is response for RemoveBookBookmark operation.
+type RemoveBookBookmarkNoContent struct{}
+
+// RemoveBookFromShelfNoContent is response for RemoveBookFromShelf operation.
+type RemoveBookFromShelfNoContent struct{}
+
+// RemoveBookHighlightNoContent is response for RemoveBookHighlight operation.
+type RemoveBookHighlightNoContent struct{}
+
+// RemoveBookNoteNoContent is response for RemoveBookNote operation.
+type RemoveBookNoteNoContent struct{}
+
+// RemoveBookReviewNoContent is response for RemoveBookReview operation.
+type RemoveBookReviewNoContent struct{}
+
+// RemoveReadingPlanNoContent is response for RemoveReadingPlan operation.
+type RemoveReadingPlanNoContent struct{}
+
+// RemoveUserBookNoContent is response for RemoveUserBook operation.
+type RemoveUserBookNoContent struct{}
+
+// RemoveUserShelfNoContent is response for RemoveUserShelf operation.
+type RemoveUserShelfNoContent struct{}
+
+// User's rating and review of the book.
+// Ref: #/components/schemas/Review
+type Review struct {
+	// Author of the review.
+	UserID int `json:"user_id"`
+	// Reviewed book.
+	BookID int    `json:"book_id"`
+	Rating Rating `json:"rating"`
+	// Text of the review.
+	Text       OptString        `json:"text"`
+	Visibility ReviewVisibility `json:"visibility"`
+	// When the review was last changed.
+	Updated time.Time `json:"updated"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *Review) GetUserID() int {
+	return s.UserID
+}
+
+// GetBookID returns the value of BookID.
+func (s *Review) GetBookID() int {
+	return s.BookID
+}
+
+// GetRating returns the value of Rating.
+func (s *Review) GetRating() Rating {
+	return s.Rating
+}
+
+// GetText returns the value of Text.
+func (s *Review) GetText() OptString {
+	return s.Text
+}
+
+// GetVisibility returns the value of Visibility.
+func (s *Review) GetVisibility() ReviewVisibility {
+	return s.Visibility
+}
+
+// GetUpdated returns the value of Updated.
+func (s *Review) GetUpdated() time.Time {
+	return s.Updated
+}
+
+// SetUserID sets the value of UserID.
+func (s *Review) SetUserID(val int) {
+	s.UserID = val
+}
+
+// SetBookID sets the value of BookID.
+func (s *Review) SetBookID(val int) {
+	s.BookID = val
+}
+
+// SetRating sets the value of Rating.
+func (s *Review) SetRating(val Rating) {
+	s.Rating = val
+}
+
+// SetText sets the value of Text.
+func (s *Review) SetText(val OptString) {
+	s.Text = val
+}
+
+// SetVisibility sets the value of Visibility.
+func (s *Review) SetVisibility(val ReviewVisibility) {
+	s.Visibility = val
+}
+
+// SetUpdated sets the value of Updated.
+func (s *Review) SetUpdated(val time.Time) {
+	s.Updated = val
+}
+
+// Who can read the review, private reviews only contribute to the rating.
+// Ref: #/components/schemas/ReviewVisibility
+type ReviewVisibility string
+
+const (
+	ReviewVisibilityPrivate ReviewVisibility = "private"
+	ReviewVisibilityShared  ReviewVisibility = "shared"
+	ReviewVisibilityPublic  ReviewVisibility = "public"
+)
+
+// AllValues returns all ReviewVisibility values.
+func (ReviewVisibility) AllValues() []ReviewVisibility {
+	return []ReviewVisibility{
+		ReviewVisibilityPrivate,
+		ReviewVisibilityShared,
+		ReviewVisibilityPublic,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ReviewVisibility) MarshalText() ([]byte, error) {
+	switch s {
+	case ReviewVisibilityPrivate:
+		return []byte(s), nil
+	case ReviewVisibilityShared:
+		return []byte(s), nil
+	case ReviewVisibilityPublic:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ReviewVisibility) UnmarshalText(data []byte) error {
+	switch ReviewVisibility(data) {
+	case ReviewVisibilityPrivate:
+		*s = ReviewVisibilityPrivate
+		return nil
+	case ReviewVisibilityShared:
+		*s = ReviewVisibilityShared
+		return nil
+	case ReviewVisibilityPublic:
+		*s = ReviewVisibilityPublic
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Book matched by a search query.
+// Ref: #/components/schemas/SearchResult
+type SearchResult struct {
+	Book Book `json:"book"`
+	// Relevance of the book, greater is better.
+	Score float64 `json:"score"`
+}
+
+// GetBook returns the value of Book.
+func (s *SearchResult) GetBook() Book {
+	return s.Book
+}
+
+// GetScore returns the value of Score.
+func (s *SearchResult) GetScore() float64 {
+	return s.Score
+}
+
+// SetBook sets the value of Book.
+func (s *SearchResult) SetBook(val Book) {
+	s.Book = val
+}
+
+// SetScore sets the value of Score.
+func (s *SearchResult) SetScore(val float64) {
+	s.Score = val
+}
+
+// Series the book belongs to.
+// Ref: #/components/schemas/Series
+type Series struct {
+	// Name of the series.
+	Name string `json:"name"`
+	// Number of the book in the series, novellas between books may have fractional numbers.
+	Number OptFloat64 `json:"number"`
+}
+
+// GetName returns the value of Name.
+func (s *Series) GetName() string {
+	return s.Name
+}
+
+// GetNumber returns the value of Number.
+func (s *Series) GetNumber() OptFloat64 {
+	return s.Number
+}
+
+// SetName sets the value of Name.
+func (s *Series) SetName(val string) {
+	s.Name = val
+}
+
+// SetNumber sets the value of Number.
+func (s *Series) SetNumber(val OptFloat64) {
+	s.Number = val
+}
+
+// Ref: #/components/schemas/SeriesBooks
+type SeriesBooks struct {
+	// Name of the series.
+	Name  string `json:"name"`
+	Books []Book `json:"books"`
+}
+
+// GetName returns the value of Name.
+func (s *SeriesBooks) GetName() string {
+	return s.Name
+}
+
+// GetBooks returns the value of Books.
+func (s *SeriesBooks) GetBooks() []Book {
+	return s.Books
+}
+
+// SetName sets the value of Name.
+func (s *SeriesBooks) SetName(val string) {
+	s.Name = val
+}
+
+// SetBooks sets the value of Books.
+func (s *SeriesBooks) SetBooks(val []Book) {
+	s.Books = val
+}
+
+// User's named shelf, books may be on several shelves at once.
+// Ref: #/components/schemas/Shelf
+type Shelf struct {
+	// Unique ID of the shelf.
+	ID int `json:"id"`
+	// Name of the shelf.
+	Name string `json:"name"`
+	// Number of books on the shelf.
+	Books int `json:"books"`
+}
+
+// GetID returns the value of ID.
+func (s *Shelf) GetID() int {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Shelf) GetName() string {
+	return s.Name
+}
+
+// GetBooks returns the value of Books.
+func (s *Shelf) GetBooks() int {
+	return s.Books
+}
+
+// SetID sets the value of ID.
+func (s *Shelf) SetID(val int) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Shelf) SetName(val string) {
+	s.Name = val
+}
+
+// SetBooks sets the value of Books.
+func (s *Shelf) SetBooks(val int) {
+	s.Books = val
+}
+
+// How similarity of two books is computed from their readers: cosine is the number of common readers
+// divided by the geometric mean of readers of each book, jaccard is common readers divided by
+// readers of either book.
+// Ref: #/components/schemas/SimilarityMetric
+type SimilarityMetric string
+
+const (
+	SimilarityMetricCosine  SimilarityMetric = "cosine"
+	SimilarityMetricJaccard SimilarityMetric = "jaccard"
+)
+
+// AllValues returns all SimilarityMetric values.
+func (SimilarityMetric) AllValues() []SimilarityMetric {
+	return []SimilarityMetric{
+		SimilarityMetricCosine,
+		SimilarityMetricJaccard,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SimilarityMetric) MarshalText() ([]byte, error) {
+	switch s {
+	case SimilarityMetricCosine:
+		return []byte(s), nil
+	case SimilarityMetricJaccard:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SimilarityMetric) UnmarshalText(data []byte) error {
+	switch SimilarityMetric(data) {
+	case SimilarityMetricCosine:
+		*s = SimilarityMetricCosine
+		return nil
+	case SimilarityMetricJaccard:
+		*s = SimilarityMetricJaccard
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// UnfollowUserNoContent is response for UnfollowUser operation.
+type UnfollowUserNoContent struct{}
+
+type UpdateReadingProgressReq struct {
+	// New current page, or position in the unit of the edition being read.
+	Page   int              `json:"page"`
+	Unit   OptProgressUnit  `json:"unit"`
+	Status OptReadingStatus `json:"status"`
+}
+
+// GetPage returns the value of Page.
+func (s *UpdateReadingProgressReq) GetPage() int {
+	return s.Page
+}
+
+// GetUnit returns the value of Unit.
+func (s *UpdateReadingProgressReq) GetUnit() OptProgressUnit {
+	return s.Unit
+}
+
+// GetStatus returns the value of Status.
+func (s *UpdateReadingProgressReq) GetStatus() OptReadingStatus {
+	return s.Status
+}
+
+// SetPage sets the value of Page.
+func (s *UpdateReadingProgressReq) SetPage(val int) {
+	s.Page = val
+}
+
+// SetUnit sets the value of Unit.
+func (s *UpdateReadingProgressReq) SetUnit(val OptProgressUnit) {
+	s.Unit = val
+}
+
+// SetStatus sets the value of Status.
+func (s *UpdateReadingProgressReq) SetStatus(val OptReadingStatus) {
+	s.Status = val
+}
+
+// Ref: #/components/schemas/Weekday
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "monday"
+	WeekdayTuesday   Weekday = "tuesday"
+	WeekdayWednesday Weekday = "wednesday"
+	WeekdayThursday  Weekday = "thursday"
+	WeekdayFriday    Weekday = "friday"
+	WeekdaySaturday  Weekday = "saturday"
+	WeekdaySunday    Weekday = "sunday"
+)
+
+// AllValues returns all Weekday values.
+func (Weekday) AllValues() []Weekday {
+	return []Weekday{
+		WeekdayMonday,
+		WeekdayTuesday,
+		WeekdayWednesday,
+		WeekdayThursday,
+		WeekdayFriday,
+		WeekdaySaturday,
+		WeekdaySunday,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Weekday) MarshalText() ([]byte, error) {
+	switch s {
+	case WeekdayMonday:
+		return []byte(s), nil
+	case WeekdayTuesday:
+		return []byte(s), nil
+	case WeekdayWednesday:
+		return []byte(s), nil
+	case WeekdayThursday:
+		return []byte(s), nil
+	case WeekdayFriday:
+		return []byte(s), nil
+	case WeekdaySaturday:
+		return []byte(s), nil
+	case WeekdaySunday:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Weekday) UnmarshalText(data []byte) error {
+	switch Weekday(data) {
+	case WeekdayMonday:
+		*s = WeekdayMonday
+		return nil
+	case WeekdayTuesday:
+		*s = WeekdayTuesday
+		return nil
+	case WeekdayWednesday:
+		*s = WeekdayWednesday
+		return nil
+	case WeekdayThursday:
+		*s = WeekdayThursday
+		return nil
+	case WeekdayFriday:
+		*s = WeekdayFriday
+		return nil
+	case WeekdaySaturday:
+		*s = WeekdaySaturday
+		return nil
+	case WeekdaySunday:
+		*s = WeekdaySunday
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/YearCount
+type YearCount struct {
+	Year  int `json:"year"`
+	Books int `json:"books"`
+}
+
+// GetYear returns the value of Year.
+func (s *YearCount) GetYear() int {
+	return s.Year
+}
+
+// GetBooks returns the value of Books.
+func (s *YearCount) GetBooks() int {
+	return s.Books
+}
+
+// SetYear sets the value of Year.
+func (s *YearCount) SetYear(val int) {
+	s.Year = val
+}
+
+// SetBooks sets the value of Books.
+func (s *YearCount) SetBooks(val int) {
+	s.Books = val
+}
+
+// Totals of the current year regardless of the range.
+// Ref: #/components/schemas/YearTotals
+type YearTotals struct {
+	Year int `json:"year"`
+	// Books finished this year.
+	Books int `json:"books"`
+	// Pages read this year.
+	Pages int `json:"pages"`
+}
+
+// GetYear returns the value of Year.
+func (s *YearTotals) GetYear() int {
+	return s.Year
+}
+
+// GetBooks returns the value of Books.
+func (s *YearTotals) GetBooks() int {
+	return s.Books
+}
+
+// GetPages returns the value of Pages.
+func (s *YearTotals) GetPages() int {
+	return s.Pages
+}
+
+// SetYear sets the value of Year.
+func (s *YearTotals) SetYear(val int) {
+	s.Year = val
+}
+
+// SetBooks sets the value of Books.
+func (s *YearTotals) SetBooks(val int) {
+	s.Books = val
+}
+
+// SetPages sets the value of Pages.
+func (s *YearTotals) SetPages(val int) {
+	s.Pages = val
+}
@@ -8,36 +8,492 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// AddBookBookmark implements addBookBookmark operation.
+	//
+	// Adds a bookmark to the book, either page or location is required.
+	//
+	// POST /users/{user_id}/books/{book_id}/bookmarks
+	AddBookBookmark(ctx context.Context, req *NewBookmark, params AddBookBookmarkParams) (*Bookmark, error)
+	// AddBookEdition implements addBookEdition operation.
+	//
+	// Adds an edition of the book, e.g. an audiobook in addition to the paper one.
+	//
+	// POST /users/{user_id}/books/{book_id}/editions
+	AddBookEdition(ctx context.Context, req *NewEdition, params AddBookEditionParams) (*Edition, error)
+	// AddBookHighlight implements addBookHighlight operation.
+	//
+	// Adds a quoted text of the book, color is yellow by default.
+	//
+	// POST /users/{user_id}/books/{book_id}/highlights
+	AddBookHighlight(ctx context.Context, req *NewHighlight, params AddBookHighlightParams) (*Highlight, error)
+	// AddBookNote implements addBookNote operation.
+	//
+	// Adds a note to the book.
+	//
+	// POST /users/{user_id}/books/{book_id}/notes
+	AddBookNote(ctx context.Context, req *NewNote, params AddBookNoteParams) (*Note, error)
+	// AddBookToShelf implements addBookToShelf operation.
+	//
+	// Puts the book on the shelf, a book may be on any number of shelves.
+	//
+	// PUT /users/{user_id}/shelves/{shelf_id}/books/{book_id}
+	AddBookToShelf(ctx context.Context, params AddBookToShelfParams) error
 	// AddUserBook implements addUserBook operation.
 	//
-	// Appends a book to user's list if the list doesn't contain it, if contains, an error returned.
+	// Appends a book to user's list if the list doesn't contain it, if contains, an error returned. If
+	// ISBN is given, missing title, author and publication date are filled from the catalog.
 	//
 	// POST /users/{user_id}/books
-	AddUserBook(ctx context.Context, req *Book, params AddUserBookParams) (AddUserBookRes, error)
+	AddUserBook(ctx context.Context, req *NewBook, params AddUserBookParams) (*Book, error)
+	// AddUserBookEpub implements addUserBookEpub operation.
+	//
+	// Adds a book described by the uploaded EPUB file. Title, authors, publication date, language and
+	// ISBN are taken from the package metadata, the number of pages is estimated by the length of the
+	// text. Then the book is added the same way as in addUserBook.
+	//
+	// POST /users/{user_id}/books/epub
+	AddUserBookEpub(ctx context.Context, req *AddUserBookEpubReq, params AddUserBookEpubParams) (*Book, error)
+	// AddUserShelf implements addUserShelf operation.
+	//
+	// Creates an empty shelf.
+	//
+	// POST /users/{user_id}/shelves
+	AddUserShelf(ctx context.Context, req *NewShelf, params AddUserShelfParams) (*Shelf, error)
+	// CreateClub implements createClub operation.
+	//
+	// Creates a reading club, its owner becomes the first member.
+	//
+	// POST /clubs
+	CreateClub(ctx context.Context, req *NewClub) (*Club, error)
+	// ExportBookHighlights implements exportBookHighlights operation.
+	//
+	// Returns all highlights of the book as Markdown document with quotes ordered by their position in
+	// the book.
+	//
+	// GET /users/{user_id}/books/{book_id}/highlights/export
+	ExportBookHighlights(ctx context.Context, params ExportBookHighlightsParams) (ExportBookHighlightsOK, error)
+	// ExportReadingPlans implements exportReadingPlans operation.
+	//
+	// Returns days of all user's reading plans as an iCalendar (RFC 5545) feed calendar apps can
+	// subscribe to.
+	//
+	// GET /users/{user_id}/plan.ics
+	ExportReadingPlans(ctx context.Context, params ExportReadingPlansParams) (ExportReadingPlansOK, error)
+	// ExportUserBooks implements exportUserBooks operation.
+	//
+	// Streams all user's books as CSV with header id,title,author,published,pages,language,page,status,
+	// finished,isbn10,isbn13,reads or as JSON Lines with a book per line. The reads column holds
+	// read-throughs as a JSON array, so rereads survive export and import in both formats. CSV has only
+	// these columns, JSON Lines keeps every field of the book.
+	//
+	// GET /users/{user_id}/books/export
+	ExportUserBooks(ctx context.Context, params ExportUserBooksParams) (ExportUserBooksRes, error)
+	// FindAuthors implements findAuthors operation.
+	//
+	// Finds authors by name. Names are compared ignoring case, order of first and last name and the
+	// difference between Cyrillic spelling and its transliterations, so "Фёдор
+	// Достоевский" finds "Fyodor Dostoyevsky".
+	//
+	// GET /authors
+	FindAuthors(ctx context.Context, params FindAuthorsParams) ([]Author, error)
+	// FollowUser implements followUser operation.
+	//
+	// Follows the user, their activity from now on appears in the feed.
+	//
+	// PUT /users/{user_id}/following/{followee_id}
+	FollowUser(ctx context.Context, params FollowUserParams) error
+	// GetAuthor implements getAuthor operation.
+	//
+	// Returns the author with all spellings of the name met in books.
+	//
+	// GET /authors/{author_id}
+	GetAuthor(ctx context.Context, params GetAuthorParams) (*Author, error)
+	// GetBookBookmark implements getBookBookmark operation.
+	//
+	// Returns a bookmark by its id.
+	//
+	// GET /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+	GetBookBookmark(ctx context.Context, params GetBookBookmarkParams) (*Bookmark, error)
+	// GetBookBookmarks implements getBookBookmarks operation.
+	//
+	// Returns bookmarks of the book ordered by their position in the book.
+	//
+	// GET /users/{user_id}/books/{book_id}/bookmarks
+	GetBookBookmarks(ctx context.Context, params GetBookBookmarksParams) ([]Bookmark, error)
+	// GetBookCover implements getBookCover operation.
+	//
+	// Returns cover image of the book or its thumbnail. The response can be cached, ETag is the hash of
+	// the image.
+	//
+	// GET /users/{user_id}/books/{book_id}/cover
+	GetBookCover(ctx context.Context, params GetBookCoverParams) (GetBookCoverRes, error)
+	// GetBookHighlight implements getBookHighlight operation.
+	//
+	// Returns a highlight by its id.
+	//
+	// GET /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+	GetBookHighlight(ctx context.Context, params GetBookHighlightParams) (*Highlight, error)
+	// GetBookHighlights implements getBookHighlights operation.
+	//
+	// Returns highlights of the book ordered by their position in the book.
+	//
+	// GET /users/{user_id}/books/{book_id}/highlights
+	GetBookHighlights(ctx context.Context, params GetBookHighlightsParams) ([]Highlight, error)
+	// GetBookNote implements getBookNote operation.
+	//
+	// Returns a note by its id.
+	//
+	// GET /users/{user_id}/books/{book_id}/notes/{note_id}
+	GetBookNote(ctx context.Context, params GetBookNoteParams) (*Note, error)
+	// GetBookNotes implements getBookNotes operation.
+	//
+	// Returns notes of the book ordered by page.
+	//
+	// GET /users/{user_id}/books/{book_id}/notes
+	GetBookNotes(ctx context.Context, params GetBookNotesParams) ([]Note, error)
+	// GetBookReview implements getBookReview operation.
+	//
+	// Returns user's review of the book.
+	//
+	// GET /users/{user_id}/books/{book_id}/review
+	GetBookReview(ctx context.Context, params GetBookReviewParams) (*Review, error)
+	// GetBookReviews implements getBookReviews operation.
+	//
+	// Returns rating of the book across all users and the reviews with text visible to the viewer,
+	// newest first. Public reviews are visible to everyone, shared ones only to followers of the author,
+	// private ones only to the author.
+	//
+	// GET /books/{book_id}/reviews
+	GetBookReviews(ctx context.Context, params GetBookReviewsParams) (*BookReviews, error)
+	// GetClub implements getClub operation.
+	//
+	// Returns the club with its members, invited users and the current book.
+	//
+	// GET /clubs/{club_id}
+	GetClub(ctx context.Context, params GetClubParams) (*Club, error)
+	// GetClubEvents implements getClubEvents operation.
+	//
+	// Returns events of the club from the oldest, optionally only the ones after the given event.
+	//
+	// GET /clubs/{club_id}/events
+	GetClubEvents(ctx context.Context, params GetClubEventsParams) ([]ClubEvent, error)
+	// GetClubProgress implements getClubProgress operation.
+	//
+	// Returns progress of every member on the current book side by side, ordered from the furthest.
+	// Progress is taken from members' own books, members without the book are at the start.
+	//
+	// GET /clubs/{club_id}/progress
+	GetClubProgress(ctx context.Context, params GetClubProgressParams) ([]MemberProgress, error)
+	// GetFeed implements getFeed operation.
+	//
+	// Returns activity of followed users, newest first: books they started and finished and ratings with
+	// reviews visible to the user. Activity is delivered to followers' feeds when it happens, only the
+	// latest activity is kept.
+	//
+	// GET /users/{user_id}/feed
+	GetFeed(ctx context.Context, params GetFeedParams) (*FeedPage, error)
+	// GetFollowers implements getFollowers operation.
+	//
+	// Returns IDs of users following the user in ascending order.
+	//
+	// GET /users/{user_id}/followers
+	GetFollowers(ctx context.Context, params GetFollowersParams) ([]int, error)
+	// GetFollowing implements getFollowing operation.
+	//
+	// Returns IDs of users the user follows in ascending order.
+	//
+	// GET /users/{user_id}/following
+	GetFollowing(ctx context.Context, params GetFollowingParams) ([]int, error)
+	// GetLocale implements getLocale operation.
+	//
+	// Returns the language of error messages for requests about the user without a supported
+	// Accept-Language.
+	//
+	// GET /users/{user_id}/locale
+	GetLocale(ctx context.Context, params GetLocaleParams) (*Locale, error)
+	// GetPrivacy implements getPrivacy operation.
+	//
+	// Returns privacy settings of the user.
+	//
+	// GET /users/{user_id}/privacy
+	GetPrivacy(ctx context.Context, params GetPrivacyParams) (*Privacy, error)
+	// GetReadingPlan implements getReadingPlan operation.
+	//
+	// Returns the reading plan of the book from today to the deadline. The plan follows actual progress:
+	// if today's part is read, the rest is spread over the following days, missed days are spread over
+	// the remaining ones.
+	//
+	// GET /users/{user_id}/books/{book_id}/plan
+	GetReadingPlan(ctx context.Context, params GetReadingPlanParams) (*ReadingPlan, error)
+	// GetReadingQueue implements getReadingQueue operation.
+	//
+	// Returns books the user wants to read in the order the user is going to read them.
+	//
+	// GET /users/{user_id}/queue
+	GetReadingQueue(ctx context.Context, params GetReadingQueueParams) ([]QueueItem, error)
+	// GetRecommendations implements getRecommendations operation.
+	//
+	// Recommends books the user doesn't have, by the principle "readers of X also read Y". Similarity of
+	// two books is computed from how many users have both of them on their shelves, a book is scored by
+	// its similarity to all user's books. Co-reading counts are updated on every change of shelves.
+	//
+	// GET /users/{user_id}/recommendations
+	GetRecommendations(ctx context.Context, params GetRecommendationsParams) ([]Recommendation, error)
 	// GetUserBook implements getUserBook operation.
 	//
 	// Returns a book by user's and book's ids.
 	//
 	// GET /users/{user_id}/books/{book_id}
-	GetUserBook(ctx context.Context, params GetUserBookParams) (GetUserBookRes, error)
+	GetUserBook(ctx context.Context, params GetUserBookParams) (*Book, error)
 	// GetUserBooks implements getUserBooks operation.
 	//
-	// Returns list of user's books by their id.
+	// Returns list of user's books by their id, optionally only the books on the given shelf.
 	//
 	// GET /users/{user_id}/books
 	GetUserBooks(ctx context.Context, params GetUserBooksParams) ([]Book, error)
+	// GetUserBooksByAuthor implements getUserBooksByAuthor operation.
+	//
+	// Returns user's books the author contributed to, optionally only in the given role.
+	//
+	// GET /users/{user_id}/authors/{author_id}/books
+	GetUserBooksByAuthor(ctx context.Context, params GetUserBooksByAuthorParams) ([]Book, error)
+	// GetUserSeries implements getUserSeries operation.
+	//
+	// Returns series of user's books ordered by name, books of a series are ordered by their number in it.
+	//
+	// GET /users/{user_id}/series
+	GetUserSeries(ctx context.Context, params GetUserSeriesParams) ([]SeriesBooks, error)
+	// GetUserShelves implements getUserShelves operation.
+	//
+	// Returns user's shelves ordered by name.
+	//
+	// GET /users/{user_id}/shelves
+	GetUserShelves(ctx context.Context, params GetUserShelvesParams) ([]Shelf, error)
+	// GetUserStats implements getUserStats operation.
+	//
+	// Returns statistics of user's reading in the date range. Finished books are counted by
+	// read-throughs, so a reread book counts once for every reading. Pages are counted from the history
+	// of progress updates, days are taken in the given time zone.
+	//
+	// GET /users/{user_id}/stats
+	GetUserStats(ctx context.Context, params GetUserStatsParams) (*ReadingStats, error)
+	// GetYearReport implements getYearReport operation.
+	//
+	// Returns the year in review as a standalone HTML page to share: books finished, pages read, the
+	// longest book, the fastest read and monthly bar charts drawn as inline SVG. The report is built
+	// from read-throughs and progress history, rereads count as separate books.
+	//
+	// GET /users/{user_id}/reports/{year}
+	GetYearReport(ctx context.Context, params GetYearReportParams) (GetYearReportOK, error)
+	// ImportGoodreads implements importGoodreads operation.
+	//
+	// Adds books from Goodreads library export. Exclusive shelf is mapped to the status (to-read,
+	// currently-reading, read) and the read date to the finish date. Books on other exclusive shelves, e.
+	// g. abandoned, are added as wanted to read and put on the user's shelf of that name. Shelves from
+	// the Bookshelves column become user's shelves too, missing shelves are created. Conflicts are
+	// resolved the same way as in importUserBooks.
+	//
+	// POST /users/{user_id}/books/import/goodreads
+	ImportGoodreads(ctx context.Context, req ImportGoodreadsReq, params ImportGoodreadsParams) (*ImportReport, error)
+	// ImportKindleClippings implements importKindleClippings operation.
+	//
+	// Adds highlights from Kindle "My Clippings.txt". Highlights are attached to the shelf book with the
+	// same title, books missing on the shelf are created as being read with an open read-through,
+	// highlights don't mean the book is finished. Notes and bookmarks are skipped.
+	//
+	// POST /users/{user_id}/books/import/kindle
+	ImportKindleClippings(ctx context.Context, req ImportKindleClippingsReq, params ImportKindleClippingsParams) (*ImportReport, error)
+	// ImportUserBooks implements importUserBooks operation.
+	//
+	// Adds books from CSV or JSON Lines in the format of the export, the format is chosen by
+	// Content-Type. Rows are checked the same way as in addUserBook, invalid rows are reported and
+	// skipped. A row with a book the user already has is a conflict, resolved according to on_conflict.
+	// In dry run mode the report is built but the shelf is not changed.
+	//
+	// POST /users/{user_id}/books/import
+	ImportUserBooks(ctx context.Context, req ImportUserBooksReq, params ImportUserBooksParams) (*ImportReport, error)
+	// InviteToClub implements inviteToClub operation.
+	//
+	// Invites the user to the club, the user becomes a member after accepting the invitation.
+	//
+	// POST /clubs/{club_id}/invites
+	InviteToClub(ctx context.Context, req *ClubUser, params InviteToClubParams) (*Club, error)
+	// JoinClub implements joinClub operation.
+	//
+	// Accepts the invitation to the club.
+	//
+	// POST /clubs/{club_id}/members
+	JoinClub(ctx context.Context, req *ClubUser, params JoinClubParams) (*Club, error)
+	// LeaveClub implements leaveClub operation.
+	//
+	// Removes the member from the club, the owner can't leave.
+	//
+	// DELETE /clubs/{club_id}/members/{user_id}
+	LeaveClub(ctx context.Context, params LeaveClubParams) error
+	// MoveInReadingQueue implements moveInReadingQueue operation.
+	//
+	// Moves the book to the given position of the queue, other books keep their order.
+	//
+	// PUT /users/{user_id}/queue/{book_id}
+	MoveInReadingQueue(ctx context.Context, req *QueueMove, params MoveInReadingQueueParams) (*QueueItem, error)
+	// PickClubBook implements pickClubBook operation.
+	//
+	// Picks the book the club reads now with its milestones. Milestones are positions in percent, so
+	// members may read different editions; a milestone is reached when every member is past it.
+	//
+	// PUT /clubs/{club_id}/book
+	PickClubBook(ctx context.Context, req *ClubBook, params PickClubBookParams) (*Club, error)
+	// PopReadingQueue implements popReadingQueue operation.
+	//
+	// Takes the first book of the queue and starts reading it.
+	//
+	// POST /users/{user_id}/queue/pop
+	PopReadingQueue(ctx context.Context, params PopReadingQueueParams) (*Book, error)
+	// PutBookCover implements putBookCover operation.
+	//
+	// Sets cover of the book from JPEG or PNG image up to 10 MiB and 40 megapixels, a thumbnail is
+	// generated along with it. Images are stored by hash of their content, so the same cover of
+	// different users is stored once.
+	//
+	// PUT /users/{user_id}/books/{book_id}/cover
+	PutBookCover(ctx context.Context, req PutBookCoverReq, params PutBookCoverParams) (*Cover, error)
+	// PutBookReview implements putBookReview operation.
+	//
+	// Rates and reviews the book or replaces the existing review. Only read books can be reviewed.
+	//
+	// PUT /users/{user_id}/books/{book_id}/review
+	PutBookReview(ctx context.Context, req *NewReview, params PutBookReviewParams) (*Review, error)
+	// RemoveBookBookmark implements removeBookBookmark operation.
+	//
+	// Removes a bookmark by id if exists, otherwise an error returned.
+	//
+	// DELETE /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+	RemoveBookBookmark(ctx context.Context, params RemoveBookBookmarkParams) error
+	// RemoveBookFromShelf implements removeBookFromShelf operation.
+	//
+	// Takes the book off the shelf, the book stays in user's library.
+	//
+	// DELETE /users/{user_id}/shelves/{shelf_id}/books/{book_id}
+	RemoveBookFromShelf(ctx context.Context, params RemoveBookFromShelfParams) error
+	// RemoveBookHighlight implements removeBookHighlight operation.
+	//
+	// Removes a highlight by id if exists, otherwise an error returned.
+	//
+	// DELETE /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+	RemoveBookHighlight(ctx context.Context, params RemoveBookHighlightParams) error
+	// RemoveBookNote implements removeBookNote operation.
+	//
+	// Removes a note by id if exists, otherwise an error returned.
+	//
+	// DELETE /users/{user_id}/books/{book_id}/notes/{note_id}
+	RemoveBookNote(ctx context.Context, params RemoveBookNoteParams) error
+	// RemoveBookReview implements removeBookReview operation.
+	//
+	// Removes user's review of the book.
+	//
+	// DELETE /users/{user_id}/books/{book_id}/review
+	RemoveBookReview(ctx context.Context, params RemoveBookReviewParams) error
+	// RemoveReadingPlan implements removeReadingPlan operation.
+	//
+	// Removes the reading plan of the book, the plan is also removed when the book is read.
+	//
+	// DELETE /users/{user_id}/books/{book_id}/plan
+	RemoveReadingPlan(ctx context.Context, params RemoveReadingPlanParams) error
 	// RemoveUserBook implements removeUserBook operation.
 	//
 	// Removes a book by id if exists, otherwise an error returned.
 	//
 	// DELETE /users/{user_id}/books/{book_id}
-	RemoveUserBook(ctx context.Context, params RemoveUserBookParams) (RemoveUserBookRes, error)
+	RemoveUserBook(ctx context.Context, params RemoveUserBookParams) error
+	// RemoveUserShelf implements removeUserShelf operation.
+	//
+	// Removes the shelf, books on it stay in user's library.
+	//
+	// DELETE /users/{user_id}/shelves/{shelf_id}
+	RemoveUserShelf(ctx context.Context, params RemoveUserShelfParams) error
+	// RenameUserShelf implements renameUserShelf operation.
+	//
+	// Renames the shelf, books stay on it.
+	//
+	// PUT /users/{user_id}/shelves/{shelf_id}
+	RenameUserShelf(ctx context.Context, req *NewShelf, params RenameUserShelfParams) (*Shelf, error)
+	// SearchUserBooks implements searchUserBooks operation.
+	//
+	// Searches user's books by words of the title and the author name. Case and ё/е differences are
+	// ignored, the last word of the query matches as a prefix, results are ranked by relevance.
+	//
+	// GET /users/{user_id}/books/search
+	SearchUserBooks(ctx context.Context, params SearchUserBooksParams) ([]SearchResult, error)
+	// SetLocale implements setLocale operation.
+	//
+	// Changes the language of error messages for requests about the user without a supported
+	// Accept-Language.
+	//
+	// PUT /users/{user_id}/locale
+	SetLocale(ctx context.Context, req *Locale, params SetLocaleParams) (*Locale, error)
+	// SetPrivacy implements setPrivacy operation.
+	//
+	// Changes privacy settings, hiding activity also hides the earlier activity from followers' feeds.
+	//
+	// PUT /users/{user_id}/privacy
+	SetPrivacy(ctx context.Context, req *Privacy, params SetPrivacyParams) (*Privacy, error)
+	// SetReadingPlan implements setReadingPlan operation.
+	//
+	// Plans to finish the book by the deadline, splitting the rest of the book evenly between days
+	// except rest days.
+	//
+	// PUT /users/{user_id}/books/{book_id}/plan
+	SetReadingPlan(ctx context.Context, req *NewReadingPlan, params SetReadingPlanParams) (*ReadingPlan, error)
+	// StartReread implements startReread operation.
+	//
+	// Starts a new read-through of the book from the first page, earlier read-throughs are kept.
+	//
+	// POST /users/{user_id}/books/{book_id}/reads
+	StartReread(ctx context.Context, params StartRereadParams) (*Book, error)
+	// SwitchBookEdition implements switchBookEdition operation.
+	//
+	// Switches the edition the user reads. Progress is carried over by percentage, e.g. page 150 of 300
+	// becomes second 18000 of a 36000 seconds audiobook.
+	//
+	// PUT /users/{user_id}/books/{book_id}/edition
+	SwitchBookEdition(ctx context.Context, req *EditionSwitch, params SwitchBookEditionParams) (*Book, error)
+	// UnfollowUser implements unfollowUser operation.
+	//
+	// Stops following the user, their activity disappears from the feed.
+	//
+	// DELETE /users/{user_id}/following/{followee_id}
+	UnfollowUser(ctx context.Context, params UnfollowUserParams) error
+	// UpdateBookBookmark implements updateBookBookmark operation.
+	//
+	// Replaces the bookmark with a new one keeping its id and creation time.
+	//
+	// PUT /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+	UpdateBookBookmark(ctx context.Context, req *NewBookmark, params UpdateBookBookmarkParams) (*Bookmark, error)
+	// UpdateBookHighlight implements updateBookHighlight operation.
+	//
+	// Replaces the highlight with a new one keeping its id and creation time.
+	//
+	// PUT /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+	UpdateBookHighlight(ctx context.Context, req *NewHighlight, params UpdateBookHighlightParams) (*Highlight, error)
+	// UpdateBookNote implements updateBookNote operation.
+	//
+	// Replaces the note with a new one keeping its id and creation time.
+	//
+	// PUT /users/{user_id}/books/{book_id}/notes/{note_id}
+	UpdateBookNote(ctx context.Context, req *NewNote, params UpdateBookNoteParams) (*Note, error)
 	// UpdateReadingProgress implements updateReadingProgress operation.
 	//
-	// Sets page value to a new one, returns an error if the book doesn't exist.
+	// Sets page value of the active read-through to a new one and optionally changes the status, returns
+	// an error if the book doesn't exist. Position may be given in percent for any edition of known
+	// length. Finishing a book sets its finish date to today, starting to read a read book starts a
+	// reread.
 	//
 	// PUT /users/{user_id}/books/{book_id}
-	UpdateReadingProgress(ctx context.Context, req *UpdateReadingProgressReq, params UpdateReadingProgressParams) (UpdateReadingProgressRes, error)
+	UpdateReadingProgress(ctx context.Context, req *UpdateReadingProgressReq, params UpdateReadingProgressParams) (*Book, error)
+	// NewError creates *ErrorStatusCode from error returned by handler.
+	//
+	// Used for common default response.
+	NewError(ctx context.Context, err error) *ErrorStatusCode
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -13,12 +13,339 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// AddBookBookmark implements addBookBookmark operation.
+//
+// Adds a bookmark to the book, either page or location is required.
+//
+// POST /users/{user_id}/books/{book_id}/bookmarks
+func (UnimplementedHandler) AddBookBookmark(ctx context.Context, req *NewBookmark, params AddBookBookmarkParams) (r *Bookmark, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddBookEdition implements addBookEdition operation.
+//
+// Adds an edition of the book, e.g. an audiobook in addition to the paper one.
+//
+// POST /users/{user_id}/books/{book_id}/editions
+func (UnimplementedHandler) AddBookEdition(ctx context.Context, req *NewEdition, params AddBookEditionParams) (r *Edition, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddBookHighlight implements addBookHighlight operation.
+//
+// Adds a quoted text of the book, color is yellow by default.
+//
+// POST /users/{user_id}/books/{book_id}/highlights
+func (UnimplementedHandler) AddBookHighlight(ctx context.Context, req *NewHighlight, params AddBookHighlightParams) (r *Highlight, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddBookNote implements addBookNote operation.
+//
+// Adds a note to the book.
+//
+// POST /users/{user_id}/books/{book_id}/notes
+func (UnimplementedHandler) AddBookNote(ctx context.Context, req *NewNote, params AddBookNoteParams) (r *Note, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddBookToShelf implements addBookToShelf operation.
+//
+// Puts the book on the shelf, a book may be on any number of shelves.
+//
+// PUT /users/{user_id}/shelves/{shelf_id}/books/{book_id}
+func (UnimplementedHandler) AddBookToShelf(ctx context.Context, params AddBookToShelfParams) error {
+	return ht.ErrNotImplemented
+}
+
 // AddUserBook implements addUserBook operation.
 //
-// Appends a book to user's list if the list doesn't contain it, if contains, an error returned.
+// Appends a book to user's list if the list doesn't contain it, if contains, an error returned. If
+// ISBN is given, missing title, author and publication date are filled from the catalog.
 //
 // POST /users/{user_id}/books
-func (UnimplementedHandler) AddUserBook(ctx context.Context, req *Book, params AddUserBookParams) (r AddUserBookRes, _ error) {
+func (UnimplementedHandler) AddUserBook(ctx context.Context, req *NewBook, params AddUserBookParams) (r *Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddUserBookEpub implements addUserBookEpub operation.
+//
+// Adds a book described by the uploaded EPUB file. Title, authors, publication date, language and
+// ISBN are taken from the package metadata, the number of pages is estimated by the length of the
+// text. Then the book is added the same way as in addUserBook.
+//
+// POST /users/{user_id}/books/epub
+func (UnimplementedHandler) AddUserBookEpub(ctx context.Context, req *AddUserBookEpubReq, params AddUserBookEpubParams) (r *Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// AddUserShelf implements addUserShelf operation.
+//
+// Creates an empty shelf.
+//
+// POST /users/{user_id}/shelves
+func (UnimplementedHandler) AddUserShelf(ctx context.Context, req *NewShelf, params AddUserShelfParams) (r *Shelf, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateClub implements createClub operation.
+//
+// Creates a reading club, its owner becomes the first member.
+//
+// POST /clubs
+func (UnimplementedHandler) CreateClub(ctx context.Context, req *NewClub) (r *Club, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportBookHighlights implements exportBookHighlights operation.
+//
+// Returns all highlights of the book as Markdown document with quotes ordered by their position in
+// the book.
+//
+// GET /users/{user_id}/books/{book_id}/highlights/export
+func (UnimplementedHandler) ExportBookHighlights(ctx context.Context, params ExportBookHighlightsParams) (r ExportBookHighlightsOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportReadingPlans implements exportReadingPlans operation.
+//
+// Returns days of all user's reading plans as an iCalendar (RFC 5545) feed calendar apps can
+// subscribe to.
+//
+// GET /users/{user_id}/plan.ics
+func (UnimplementedHandler) ExportReadingPlans(ctx context.Context, params ExportReadingPlansParams) (r ExportReadingPlansOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportUserBooks implements exportUserBooks operation.
+//
+// Streams all user's books as CSV with header id,title,author,published,pages,language,page,status,
+// finished,isbn10,isbn13,reads or as JSON Lines with a book per line. The reads column holds
+// read-throughs as a JSON array, so rereads survive export and import in both formats. CSV has only
+// these columns, JSON Lines keeps every field of the book.
+//
+// GET /users/{user_id}/books/export
+func (UnimplementedHandler) ExportUserBooks(ctx context.Context, params ExportUserBooksParams) (r ExportUserBooksRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// FindAuthors implements findAuthors operation.
+//
+// Finds authors by name. Names are compared ignoring case, order of first and last name and the
+// difference between Cyrillic spelling and its transliterations, so "Фёдор
+// Достоевский" finds "Fyodor Dostoyevsky".
+//
+// GET /authors
+func (UnimplementedHandler) FindAuthors(ctx context.Context, params FindAuthorsParams) (r []Author, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// FollowUser implements followUser operation.
+//
+// Follows the user, their activity from now on appears in the feed.
+//
+// PUT /users/{user_id}/following/{followee_id}
+func (UnimplementedHandler) FollowUser(ctx context.Context, params FollowUserParams) error {
+	return ht.ErrNotImplemented
+}
+
+// GetAuthor implements getAuthor operation.
+//
+// Returns the author with all spellings of the name met in books.
+//
+// GET /authors/{author_id}
+func (UnimplementedHandler) GetAuthor(ctx context.Context, params GetAuthorParams) (r *Author, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookBookmark implements getBookBookmark operation.
+//
+// Returns a bookmark by its id.
+//
+// GET /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+func (UnimplementedHandler) GetBookBookmark(ctx context.Context, params GetBookBookmarkParams) (r *Bookmark, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookBookmarks implements getBookBookmarks operation.
+//
+// Returns bookmarks of the book ordered by their position in the book.
+//
+// GET /users/{user_id}/books/{book_id}/bookmarks
+func (UnimplementedHandler) GetBookBookmarks(ctx context.Context, params GetBookBookmarksParams) (r []Bookmark, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookCover implements getBookCover operation.
+//
+// Returns cover image of the book or its thumbnail. The response can be cached, ETag is the hash of
+// the image.
+//
+// GET /users/{user_id}/books/{book_id}/cover
+func (UnimplementedHandler) GetBookCover(ctx context.Context, params GetBookCoverParams) (r GetBookCoverRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookHighlight implements getBookHighlight operation.
+//
+// Returns a highlight by its id.
+//
+// GET /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+func (UnimplementedHandler) GetBookHighlight(ctx context.Context, params GetBookHighlightParams) (r *Highlight, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookHighlights implements getBookHighlights operation.
+//
+// Returns highlights of the book ordered by their position in the book.
+//
+// GET /users/{user_id}/books/{book_id}/highlights
+func (UnimplementedHandler) GetBookHighlights(ctx context.Context, params GetBookHighlightsParams) (r []Highlight, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookNote implements getBookNote operation.
+//
+// Returns a note by its id.
+//
+// GET /users/{user_id}/books/{book_id}/notes/{note_id}
+func (UnimplementedHandler) GetBookNote(ctx context.Context, params GetBookNoteParams) (r *Note, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookNotes implements getBookNotes operation.
+//
+// Returns notes of the book ordered by page.
+//
+// GET /users/{user_id}/books/{book_id}/notes
+func (UnimplementedHandler) GetBookNotes(ctx context.Context, params GetBookNotesParams) (r []Note, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookReview implements getBookReview operation.
+//
+// Returns user's review of the book.
+//
+// GET /users/{user_id}/books/{book_id}/review
+func (UnimplementedHandler) GetBookReview(ctx context.Context, params GetBookReviewParams) (r *Review, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBookReviews implements getBookReviews operation.
+//
+// Returns rating of the book across all users and the reviews with text visible to the viewer,
+// newest first. Public reviews are visible to everyone, shared ones only to followers of the author,
+// private ones only to the author.
+//
+// GET /books/{book_id}/reviews
+func (UnimplementedHandler) GetBookReviews(ctx context.Context, params GetBookReviewsParams) (r *BookReviews, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetClub implements getClub operation.
+//
+// Returns the club with its members, invited users and the current book.
+//
+// GET /clubs/{club_id}
+func (UnimplementedHandler) GetClub(ctx context.Context, params GetClubParams) (r *Club, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetClubEvents implements getClubEvents operation.
+//
+// Returns events of the club from the oldest, optionally only the ones after the given event.
+//
+// GET /clubs/{club_id}/events
+func (UnimplementedHandler) GetClubEvents(ctx context.Context, params GetClubEventsParams) (r []ClubEvent, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetClubProgress implements getClubProgress operation.
+//
+// Returns progress of every member on the current book side by side, ordered from the furthest.
+// Progress is taken from members' own books, members without the book are at the start.
+//
+// GET /clubs/{club_id}/progress
+func (UnimplementedHandler) GetClubProgress(ctx context.Context, params GetClubProgressParams) (r []MemberProgress, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetFeed implements getFeed operation.
+//
+// Returns activity of followed users, newest first: books they started and finished and ratings with
+// reviews visible to the user. Activity is delivered to followers' feeds when it happens, only the
+// latest activity is kept.
+//
+// GET /users/{user_id}/feed
+func (UnimplementedHandler) GetFeed(ctx context.Context, params GetFeedParams) (r *FeedPage, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetFollowers implements getFollowers operation.
+//
+// Returns IDs of users following the user in ascending order.
+//
+// GET /users/{user_id}/followers
+func (UnimplementedHandler) GetFollowers(ctx context.Context, params GetFollowersParams) (r []int, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetFollowing implements getFollowing operation.
+//
+// Returns IDs of users the user follows in ascending order.
+//
+// GET /users/{user_id}/following
+func (UnimplementedHandler) GetFollowing(ctx context.Context, params GetFollowingParams) (r []int, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetLocale implements getLocale operation.
+//
+// Returns the language of error messages for requests about the user without a supported
+// Accept-Language.
+//
+// GET /users/{user_id}/locale
+func (UnimplementedHandler) GetLocale(ctx context.Context, params GetLocaleParams) (r *Locale, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetPrivacy implements getPrivacy operation.
+//
+// Returns privacy settings of the user.
+//
+// GET /users/{user_id}/privacy
+func (UnimplementedHandler) GetPrivacy(ctx context.Context, params GetPrivacyParams) (r *Privacy, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetReadingPlan implements getReadingPlan operation.
+//
+// Returns the reading plan of the book from today to the deadline. The plan follows actual progress:
+// if today's part is read, the rest is spread over the following days, missed days are spread over
+// the remaining ones.
+//
+// GET /users/{user_id}/books/{book_id}/plan
+func (UnimplementedHandler) GetReadingPlan(ctx context.Context, params GetReadingPlanParams) (r *ReadingPlan, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetReadingQueue implements getReadingQueue operation.
+//
+// Returns books the user wants to read in the order the user is going to read them.
+//
+// GET /users/{user_id}/queue
+func (UnimplementedHandler) GetReadingQueue(ctx context.Context, params GetReadingQueueParams) (r []QueueItem, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetRecommendations implements getRecommendations operation.
+//
+// Recommends books the user doesn't have, by the principle "readers of X also read Y". Similarity of
+// two books is computed from how many users have both of them on their shelves, a book is scored by
+// its similarity to all user's books. Co-reading counts are updated on every change of shelves.
+//
+// GET /users/{user_id}/recommendations
+func (UnimplementedHandler) GetRecommendations(ctx context.Context, params GetRecommendationsParams) (r []Recommendation, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -27,33 +354,370 @@ func (UnimplementedHandler) AddUserBook(ctx context.Context, req *Book, params A
 // Returns a book by user's and book's ids.
 //
 // GET /users/{user_id}/books/{book_id}
-func (UnimplementedHandler) GetUserBook(ctx context.Context, params GetUserBookParams) (r GetUserBookRes, _ error) {
+func (UnimplementedHandler) GetUserBook(ctx context.Context, params GetUserBookParams) (r *Book, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // GetUserBooks implements getUserBooks operation.
 //
-// Returns list of user's books by their id.
+// Returns list of user's books by their id, optionally only the books on the given shelf.
 //
 // GET /users/{user_id}/books
 func (UnimplementedHandler) GetUserBooks(ctx context.Context, params GetUserBooksParams) (r []Book, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// GetUserBooksByAuthor implements getUserBooksByAuthor operation.
+//
+// Returns user's books the author contributed to, optionally only in the given role.
+//
+// GET /users/{user_id}/authors/{author_id}/books
+func (UnimplementedHandler) GetUserBooksByAuthor(ctx context.Context, params GetUserBooksByAuthorParams) (r []Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserSeries implements getUserSeries operation.
+//
+// Returns series of user's books ordered by name, books of a series are ordered by their number in it.
+//
+// GET /users/{user_id}/series
+func (UnimplementedHandler) GetUserSeries(ctx context.Context, params GetUserSeriesParams) (r []SeriesBooks, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserShelves implements getUserShelves operation.
+//
+// Returns user's shelves ordered by name.
+//
+// GET /users/{user_id}/shelves
+func (UnimplementedHandler) GetUserShelves(ctx context.Context, params GetUserShelvesParams) (r []Shelf, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserStats implements getUserStats operation.
+//
+// Returns statistics of user's reading in the date range. Finished books are counted by
+// read-throughs, so a reread book counts once for every reading. Pages are counted from the history
+// of progress updates, days are taken in the given time zone.
+//
+// GET /users/{user_id}/stats
+func (UnimplementedHandler) GetUserStats(ctx context.Context, params GetUserStatsParams) (r *ReadingStats, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetYearReport implements getYearReport operation.
+//
+// Returns the year in review as a standalone HTML page to share: books finished, pages read, the
+// longest book, the fastest read and monthly bar charts drawn as inline SVG. The report is built
+// from read-throughs and progress history, rereads count as separate books.
+//
+// GET /users/{user_id}/reports/{year}
+func (UnimplementedHandler) GetYearReport(ctx context.Context, params GetYearReportParams) (r GetYearReportOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ImportGoodreads implements importGoodreads operation.
+//
+// Adds books from Goodreads library export. Exclusive shelf is mapped to the status (to-read,
+// currently-reading, read) and the read date to the finish date. Books on other exclusive shelves, e.
+// g. abandoned, are added as wanted to read and put on the user's shelf of that name. Shelves from
+// the Bookshelves column become user's shelves too, missing shelves are created. Conflicts are
+// resolved the same way as in importUserBooks.
+//
+// POST /users/{user_id}/books/import/goodreads
+func (UnimplementedHandler) ImportGoodreads(ctx context.Context, req ImportGoodreadsReq, params ImportGoodreadsParams) (r *ImportReport, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ImportKindleClippings implements importKindleClippings operation.
+//
+// Adds highlights from Kindle "My Clippings.txt". Highlights are attached to the shelf book with the
+// same title, books missing on the shelf are created as being read with an open read-through,
+// highlights don't mean the book is finished. Notes and bookmarks are skipped.
+//
+// POST /users/{user_id}/books/import/kindle
+func (UnimplementedHandler) ImportKindleClippings(ctx context.Context, req ImportKindleClippingsReq, params ImportKindleClippingsParams) (r *ImportReport, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ImportUserBooks implements importUserBooks operation.
+//
+// Adds books from CSV or JSON Lines in the format of the export, the format is chosen by
+// Content-Type. Rows are checked the same way as in addUserBook, invalid rows are reported and
+// skipped. A row with a book the user already has is a conflict, resolved according to on_conflict.
+// In dry run mode the report is built but the shelf is not changed.
+//
+// POST /users/{user_id}/books/import
+func (UnimplementedHandler) ImportUserBooks(ctx context.Context, req ImportUserBooksReq, params ImportUserBooksParams) (r *ImportReport, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// InviteToClub implements inviteToClub operation.
+//
+// Invites the user to the club, the user becomes a member after accepting the invitation.
+//
+// POST /clubs/{club_id}/invites
+func (UnimplementedHandler) InviteToClub(ctx context.Context, req *ClubUser, params InviteToClubParams) (r *Club, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// JoinClub implements joinClub operation.
+//
+// Accepts the invitation to the club.
+//
+// POST /clubs/{club_id}/members
+func (UnimplementedHandler) JoinClub(ctx context.Context, req *ClubUser, params JoinClubParams) (r *Club, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// LeaveClub implements leaveClub operation.
+//
+// Removes the member from the club, the owner can't leave.
+//
+// DELETE /clubs/{club_id}/members/{user_id}
+func (UnimplementedHandler) LeaveClub(ctx context.Context, params LeaveClubParams) error {
+	return ht.ErrNotImplemented
+}
+
+// MoveInReadingQueue implements moveInReadingQueue operation.
+//
+// Moves the book to the given position of the queue, other books keep their order.
+//
+// PUT /users/{user_id}/queue/{book_id}
+func (UnimplementedHandler) MoveInReadingQueue(ctx context.Context, req *QueueMove, params MoveInReadingQueueParams) (r *QueueItem, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PickClubBook implements pickClubBook operation.
+//
+// Picks the book the club reads now with its milestones. Milestones are positions in percent, so
+// members may read different editions; a milestone is reached when every member is past it.
+//
+// PUT /clubs/{club_id}/book
+func (UnimplementedHandler) PickClubBook(ctx context.Context, req *ClubBook, params PickClubBookParams) (r *Club, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PopReadingQueue implements popReadingQueue operation.
+//
+// Takes the first book of the queue and starts reading it.
+//
+// POST /users/{user_id}/queue/pop
+func (UnimplementedHandler) PopReadingQueue(ctx context.Context, params PopReadingQueueParams) (r *Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutBookCover implements putBookCover operation.
+//
+// Sets cover of the book from JPEG or PNG image up to 10 MiB and 40 megapixels, a thumbnail is
+// generated along with it. Images are stored by hash of their content, so the same cover of
+// different users is stored once.
+//
+// PUT /users/{user_id}/books/{book_id}/cover
+func (UnimplementedHandler) PutBookCover(ctx context.Context, req PutBookCoverReq, params PutBookCoverParams) (r *Cover, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutBookReview implements putBookReview operation.
+//
+// Rates and reviews the book or replaces the existing review. Only read books can be reviewed.
+//
+// PUT /users/{user_id}/books/{book_id}/review
+func (UnimplementedHandler) PutBookReview(ctx context.Context, req *NewReview, params PutBookReviewParams) (r *Review, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RemoveBookBookmark implements removeBookBookmark operation.
+//
+// Removes a bookmark by id if exists, otherwise an error returned.
+//
+// DELETE /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+func (UnimplementedHandler) RemoveBookBookmark(ctx context.Context, params RemoveBookBookmarkParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveBookFromShelf implements removeBookFromShelf operation.
+//
+// Takes the book off the shelf, the book stays in user's library.
+//
+// DELETE /users/{user_id}/shelves/{shelf_id}/books/{book_id}
+func (UnimplementedHandler) RemoveBookFromShelf(ctx context.Context, params RemoveBookFromShelfParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveBookHighlight implements removeBookHighlight operation.
+//
+// Removes a highlight by id if exists, otherwise an error returned.
+//
+// DELETE /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+func (UnimplementedHandler) RemoveBookHighlight(ctx context.Context, params RemoveBookHighlightParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveBookNote implements removeBookNote operation.
+//
+// Removes a note by id if exists, otherwise an error returned.
+//
+// DELETE /users/{user_id}/books/{book_id}/notes/{note_id}
+func (UnimplementedHandler) RemoveBookNote(ctx context.Context, params RemoveBookNoteParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveBookReview implements removeBookReview operation.
+//
+// Removes user's review of the book.
+//
+// DELETE /users/{user_id}/books/{book_id}/review
+func (UnimplementedHandler) RemoveBookReview(ctx context.Context, params RemoveBookReviewParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveReadingPlan implements removeReadingPlan operation.
+//
+// Removes the reading plan of the book, the plan is also removed when the book is read.
+//
+// DELETE /users/{user_id}/books/{book_id}/plan
+func (UnimplementedHandler) RemoveReadingPlan(ctx context.Context, params RemoveReadingPlanParams) error {
+	return ht.ErrNotImplemented
+}
+
 // RemoveUserBook implements removeUserBook operation.
 //
 // Removes a book by id if exists, otherwise an error returned.
 //
 // DELETE /users/{user_id}/books/{book_id}
-func (UnimplementedHandler) RemoveUserBook(ctx context.Context, params RemoveUserBookParams) (r RemoveUserBookRes, _ error) {
+func (UnimplementedHandler) RemoveUserBook(ctx context.Context, params RemoveUserBookParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RemoveUserShelf implements removeUserShelf operation.
+//
+// Removes the shelf, books on it stay in user's library.
+//
+// DELETE /users/{user_id}/shelves/{shelf_id}
+func (UnimplementedHandler) RemoveUserShelf(ctx context.Context, params RemoveUserShelfParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RenameUserShelf implements renameUserShelf operation.
+//
+// Renames the shelf, books stay on it.
+//
+// PUT /users/{user_id}/shelves/{shelf_id}
+func (UnimplementedHandler) RenameUserShelf(ctx context.Context, req *NewShelf, params RenameUserShelfParams) (r *Shelf, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchUserBooks implements searchUserBooks operation.
+//
+// Searches user's books by words of the title and the author name. Case and ё/е differences are
+// ignored, the last word of the query matches as a prefix, results are ranked by relevance.
+//
+// GET /users/{user_id}/books/search
+func (UnimplementedHandler) SearchUserBooks(ctx context.Context, params SearchUserBooksParams) (r []SearchResult, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetLocale implements setLocale operation.
+//
+// Changes the language of error messages for requests about the user without a supported
+// Accept-Language.
+//
+// PUT /users/{user_id}/locale
+func (UnimplementedHandler) SetLocale(ctx context.Context, req *Locale, params SetLocaleParams) (r *Locale, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetPrivacy implements setPrivacy operation.
+//
+// Changes privacy settings, hiding activity also hides the earlier activity from followers' feeds.
+//
+// PUT /users/{user_id}/privacy
+func (UnimplementedHandler) SetPrivacy(ctx context.Context, req *Privacy, params SetPrivacyParams) (r *Privacy, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetReadingPlan implements setReadingPlan operation.
+//
+// Plans to finish the book by the deadline, splitting the rest of the book evenly between days
+// except rest days.
+//
+// PUT /users/{user_id}/books/{book_id}/plan
+func (UnimplementedHandler) SetReadingPlan(ctx context.Context, req *NewReadingPlan, params SetReadingPlanParams) (r *ReadingPlan, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StartReread implements startReread operation.
+//
+// Starts a new read-through of the book from the first page, earlier read-throughs are kept.
+//
+// POST /users/{user_id}/books/{book_id}/reads
+func (UnimplementedHandler) StartReread(ctx context.Context, params StartRereadParams) (r *Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SwitchBookEdition implements switchBookEdition operation.
+//
+// Switches the edition the user reads. Progress is carried over by percentage, e.g. page 150 of 300
+// becomes second 18000 of a 36000 seconds audiobook.
+//
+// PUT /users/{user_id}/books/{book_id}/edition
+func (UnimplementedHandler) SwitchBookEdition(ctx context.Context, req *EditionSwitch, params SwitchBookEditionParams) (r *Book, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UnfollowUser implements unfollowUser operation.
+//
+// Stops following the user, their activity disappears from the feed.
+//
+// DELETE /users/{user_id}/following/{followee_id}
+func (UnimplementedHandler) UnfollowUser(ctx context.Context, params UnfollowUserParams) error {
+	return ht.ErrNotImplemented
+}
+
+// UpdateBookBookmark implements updateBookBookmark operation.
+//
+// Replaces the bookmark with a new one keeping its id and creation time.
+//
+// PUT /users/{user_id}/books/{book_id}/bookmarks/{bookmark_id}
+func (UnimplementedHandler) UpdateBookBookmark(ctx context.Context, req *NewBookmark, params UpdateBookBookmarkParams) (r *Bookmark, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateBookHighlight implements updateBookHighlight operation.
+//
+// Replaces the highlight with a new one keeping its id and creation time.
+//
+// PUT /users/{user_id}/books/{book_id}/highlights/{highlight_id}
+func (UnimplementedHandler) UpdateBookHighlight(ctx context.Context, req *NewHighlight, params UpdateBookHighlightParams) (r *Highlight, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateBookNote implements updateBookNote operation.
+//
+// Replaces the note with a new one keeping its id and creation time.
+//
+// PUT /users/{user_id}/books/{book_id}/notes/{note_id}
+func (UnimplementedHandler) UpdateBookNote(ctx context.Context, req *NewNote, params UpdateBookNoteParams) (r *Note, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // UpdateReadingProgress implements updateReadingProgress operation.
 //
-// Sets page value to a new one, returns an error if the book doesn't exist.
+// Sets page value of the active read-through to a new one and optionally changes the status, returns
+// an error if the book doesn't exist. Position may be given in percent for any edition of known
+// length. Finishing a book sets its finish date to today, starting to read a read book starts a
+// reread.
 //
 // PUT /users/{user_id}/books/{book_id}
-func (UnimplementedHandler) UpdateReadingProgress(ctx context.Context, req *UpdateReadingProgressReq, params UpdateReadingProgressParams) (r UpdateReadingProgressRes, _ error) {
+func (UnimplementedHandler) UpdateReadingProgress(ctx context.Context, req *UpdateReadingProgressReq, params UpdateReadingProgressParams) (r *Book, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// NewError creates *ErrorStatusCode from error returned by handler.
+//
+// Used for common default response.
+func (UnimplementedHandler) NewError(ctx context.Context, err error) (r *ErrorStatusCode) {
+	r = new(ErrorStatusCode)
+	return r
+}
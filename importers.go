@@ -0,0 +1,450 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Goodreads library export
+
+// goodreadsStatuses сопоставляет exclusive shelf из Goodreads нашим статусам
+var goodreadsStatuses = map[string]api.ReadingStatus{
+	"to-read":           api.ReadingStatusWantToRead,
+	"currently-reading": api.ReadingStatusReading,
+	"read":              api.ReadingStatusRead,
+}
+
+// goodreadsValue снимает обертку ="...", в которую Goodreads заворачивает ISBN,
+// чтобы табличные редакторы не превращали их в числа
+func goodreadsValue(v string) string {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, `="`) && strings.HasSuffix(v, `"`) {
+		v = v[2 : len(v)-1]
+	}
+	return v
+}
+
+// readGoodreadsRows читает выгрузку библиотеки Goodreads
+func readGoodreadsRows(r io.Reader, report *api.ImportReport) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range []string{"Book Id", "Title", "Author", "Exclusive Shelf"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("not a Goodreads export: missing column %q", name)
+		}
+	}
+
+	var rows []importRow
+	for n := 1; ; n++ {
+		record, err := cr.Read()
+		var parseErr *csv.ParseError
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if errors.As(err, &parseErr) {
+			rowError(report, n, "%s", parseErr.Err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return goodreadsValue(record[i])
+			}
+			return ""
+		}
+
+		id, err := strconv.Atoi(field("Book Id"))
+		if err != nil {
+			rowError(report, n, "invalid book id %q", field("Book Id"))
+			continue
+		}
+		// свои exclusive shelf у Goodreads, например abandoned, у нас становятся полками, а книга на них -
+		// книгой, которую хотят прочитать. Остальные полки пользователя перечислены в Bookshelves
+		exclusive := field("Exclusive Shelf")
+		status, ok := goodreadsStatuses[exclusive]
+		if !ok {
+			status = api.ReadingStatusWantToRead
+		}
+		shelves, e := goodreadsShelves(append(strings.Split(field("Bookshelves"), ","), exclusive))
+		if e != nil {
+			rowError(report, n, "%s", e)
+			continue
+		}
+
+		book := api.NewBook{
+			ID:     id,
+			Title:  api.NewOptString(field("Title")),
+			Author: api.NewOptString(field("Author")),
+			Status: api.NewOptReadingStatus(status),
+		}
+		// соавторов Goodreads перечисляет через запятую
+		if additional := field("Additional Authors"); additional != "" {
+			book.Contributors = []api.NewContributor{{Name: field("Author")}}
+			for _, name := range strings.Split(additional, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					book.Contributors = append(book.Contributors, api.NewContributor{Name: name})
+				}
+			}
+		}
+		if isbn := field("ISBN"); isbn != "" {
+			book.Isbn10 = api.NewOptString(isbn)
+		}
+		if isbn := field("ISBN13"); isbn != "" {
+			book.Isbn13 = api.NewOptString(isbn)
+		}
+		// год первой публикации точнее описывает произведение, чем год конкретного издания
+		for _, column := range []string{"Original Publication Year", "Year Published"} {
+			if year, err := strconv.Atoi(field(column)); err == nil && year > 0 {
+				book.Published = api.NewOptDate(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+				break
+			}
+		}
+		pages, err := strconv.Atoi(field("Number of Pages"))
+		if err == nil && pages > 0 {
+			book.Pages = api.NewOptInt(pages)
+		}
+		if status == api.ReadingStatusRead {
+			if book.Pages.Set {
+				book.Page = book.Pages
+			}
+			if read, err := time.Parse("2006/01/02", field("Date Read")); err == nil {
+				book.Finished = api.NewOptDate(read)
+			}
+		}
+		rows = append(rows, importRow{row: n, book: book, shelves: shelves})
+	}
+}
+
+// goodreadsShelves отбирает из полок Goodreads те, что не означают статус, и проверяет их имена как в AddUserShelf
+func goodreadsShelves(names []string) ([]string, error) {
+	var shelves []string
+	for _, name := range names {
+		name = cleanText(name)
+		if _, isStatus := goodreadsStatuses[name]; isStatus || name == "" ||
+			slices.ContainsFunc(shelves, func(s string) bool { return shelfKey(s) == shelfKey(name) }) {
+			continue
+		}
+		if e := checkSchema(&api.NewShelf{Name: name}); e != nil {
+			return nil, fmt.Errorf("shelf %q: %w", name, e)
+		}
+		shelves = append(shelves, name)
+	}
+	return shelves, nil
+}
+
+func (s *serviceImpl) ImportGoodreads(ctx context.Context, req api.ImportGoodreadsReq, params api.ImportGoodreadsParams) (*api.ImportReport, error) {
+	report := &api.ImportReport{
+		DryRun: params.DryRun.Or(false),
+		Errors: []api.ImportRowError{},
+	}
+
+	rows, e := readGoodreadsRows(req, report)
+	if e != nil {
+		return nil, err(api.ErrorCodeInvalidRequest, "%s", e)
+	}
+	if e := s.importBooks(ctx, params.UserID, rows, params.OnConflict.Or(api.ConflictPolicyFail), report); e != nil {
+		return nil, e
+	}
+	return report, nil
+}
+
+// Kindle "My Clippings.txt"
+
+type clippingKind int
+
+const (
+	clippingHighlight clippingKind = iota
+	clippingNote
+	clippingBookmark
+)
+
+type clipping struct {
+	// номер записи в файле для отчета
+	row       int
+	title     string
+	author    string
+	kind      clippingKind
+	highlight api.Highlight
+}
+
+const clippingSeparator = "=========="
+
+var (
+	// "Доктор Живаго (Пастернак Борис)" - автор в последних скобках
+	clippingTitleRe    = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)\s*$`)
+	clippingPageRe     = regexp.MustCompile(`(?i)(?:page|странице)\s+(\d+)`)
+	clippingLocationRe = regexp.MustCompile(`(?i)(?:location|loc\.|местоположение|позиция|месте)\s+(\d+)(?:\s*[-–]\s*(\d+))?`)
+	clippingAddedRe    = regexp.MustCompile(`Added on (.+)$`)
+	// "Добавлено: воскресенье, 15 мая 2016 г. в 20:33:15", месяц в родительном падеже
+	clippingAddedRuRe = regexp.MustCompile(`Добавлено:\s*[^,]*,\s*(\d{1,2})\s+(\p{L}+)\s+(\d{4})\s*г\.\s*в\s*(\d{1,2}:\d{2}:\d{2})`)
+)
+
+// форматы даты в англоязычных прошивках Kindle
+var clippingDateLayouts = []string{
+	"Monday, 2 January 2006 15:04:05",
+	"Monday, January 2, 2006 3:04:05 PM",
+}
+
+var clippingMonthsRu = map[string]time.Month{
+	"января": time.January, "февраля": time.February, "марта": time.March, "апреля": time.April,
+	"мая": time.May, "июня": time.June, "июля": time.July, "августа": time.August,
+	"сентября": time.September, "октября": time.October, "ноября": time.November, "декабря": time.December,
+}
+
+// parseClippingMeta разбирает строку вида
+// "- Your Highlight on page 12 | Location 170-172 | Added on Sunday, 15 May 2016 20:33:15"
+func parseClippingMeta(meta string, c *clipping) error {
+	switch lower := strings.ToLower(meta); {
+	case strings.Contains(lower, "highlight") || strings.Contains(lower, "выделен"):
+		c.kind = clippingHighlight
+	case strings.Contains(lower, "note") || strings.Contains(lower, "заметк"):
+		c.kind = clippingNote
+	case strings.Contains(lower, "bookmark") || strings.Contains(lower, "закладк"):
+		c.kind = clippingBookmark
+	default:
+		return fmt.Errorf("unknown clipping type %q", meta)
+	}
+
+	if m := clippingPageRe.FindStringSubmatch(meta); m != nil {
+		page, _ := strconv.Atoi(m[1])
+		c.highlight.PageStart = api.NewOptInt(page)
+		c.highlight.PageEnd = api.NewOptInt(page)
+	}
+	if m := clippingLocationRe.FindStringSubmatch(meta); m != nil {
+		start, _ := strconv.Atoi(m[1])
+		c.highlight.LocationStart = api.NewOptInt(start)
+		c.highlight.LocationEnd = api.NewOptInt(start)
+		if m[2] != "" {
+			end, _ := strconv.Atoi(m[2])
+			c.highlight.LocationEnd = api.NewOptInt(end)
+		}
+	}
+	if m := clippingAddedRe.FindStringSubmatch(meta); m != nil {
+		for _, layout := range clippingDateLayouts {
+			if added, err := time.Parse(layout, strings.TrimSpace(m[1])); err == nil {
+				c.highlight.Added = api.NewOptDateTime(added)
+				break
+			}
+		}
+	} else if m := clippingAddedRuRe.FindStringSubmatch(meta); m != nil {
+		// time.Parse не знает русских названий месяцев, поэтому месяц подставляется числом
+		if month, ok := clippingMonthsRu[strings.ToLower(m[2])]; ok {
+			date := fmt.Sprintf("%s %d %s %s", m[1], month, m[3], m[4])
+			if added, err := time.Parse("2 1 2006 15:04:05", date); err == nil {
+				c.highlight.Added = api.NewOptDateTime(added)
+			}
+		}
+	}
+	return nil
+}
+
+// parseClippings читает записи из "My Clippings.txt", ошибки в отдельных записях попадают в отчет
+func parseClippings(r io.Reader, report *api.ImportReport) ([]clipping, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	var clippings []clipping
+	var lines []string
+	n := 0
+	flush := func() {
+		defer func() { lines = lines[:0] }()
+		// пропускаем пустые строки в начале, например между разделителями
+		for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+			lines = lines[1:]
+		}
+		if len(lines) == 0 {
+			return
+		}
+		n++
+		if len(lines) < 2 {
+			rowError(report, n, "clipping has no description line")
+			return
+		}
+
+		c := clipping{row: n}
+		title := strings.TrimSpace(strings.TrimPrefix(lines[0], "\ufeff"))
+		if m := clippingTitleRe.FindStringSubmatch(title); m != nil {
+			c.title, c.author = m[1], strings.TrimSpace(m[2])
+		} else {
+			c.title = title
+		}
+		if err := parseClippingMeta(lines[1], &c); err != nil {
+			rowError(report, n, "%s", err)
+			return
+		}
+		c.highlight.Text = strings.TrimSpace(strings.Join(lines[2:], "\n"))
+		if c.kind == clippingHighlight && c.highlight.Text == "" {
+			rowError(report, n, "highlight has no text")
+			return
+		}
+		clippings = append(clippings, c)
+	}
+
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) == clippingSeparator {
+			flush()
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	flush()
+	return clippings, scanner.Err()
+}
+
+// id выше derivedIDBase отданы книгам, у которых нет id в общей базе, например из Kindle или EPUB.
+// id получается из названия и автора, так что у разных пользователей одна книга получит один id
+const derivedIDBase = 1 << 30
+
+func derivedBookID(title, author string) int {
+	h := fnv.New32a()
+	h.Write([]byte(titleKey(title)))
+	h.Write([]byte{0})
+	h.Write([]byte(titleKey(author)))
+	return derivedIDBase + int(h.Sum32()%derivedIDBase)
+}
+
+// titleKey нормализует название так же как поисковый индекс, чтобы "Доктор Живаго" и "доктор живаго" совпадали
+func titleKey(title string) string {
+	return strings.Join(tokenize(title), " ")
+}
+
+func sameHighlight(a, b api.Highlight) bool {
+	return a.Text == b.Text && a.LocationStart == b.LocationStart && a.PageStart == b.PageStart
+}
+
+func (s *serviceImpl) ImportKindleClippings(ctx context.Context, req api.ImportKindleClippingsReq, params api.ImportKindleClippingsParams) (*api.ImportReport, error) {
+	report := &api.ImportReport{
+		DryRun: params.DryRun.Or(false),
+		Errors: []api.ImportRowError{},
+	}
+	clippings, e := parseClippings(req, report)
+	if e != nil {
+		return nil, invalid("%s", e)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Goodreads добавляет к названию серию в скобках: "The Hunger Games (The Hunger Games, #1)",
+	// а Kindle - нет, поэтому книги с полки ищутся и по названию без скобок
+	shelf := make(map[string]int)
+	for id, book := range s.users[params.UserID] {
+		if m := clippingTitleRe.FindStringSubmatch(book.Title); m != nil {
+			shelf[titleKey(m[1])] = id
+		}
+	}
+	for id, book := range s.users[params.UserID] {
+		shelf[titleKey(book.Title)] = id
+	}
+
+	// Kindle дописывает в файл все отрывки за все время, так что при повторном
+	// импорте уже известные отрывки пропускаются
+	var newBooks []api.Book
+	highlights := make(map[int][]api.Highlight)
+	imported := 0
+	for _, c := range clippings {
+		if c.kind != clippingHighlight {
+			report.Skipped++
+			continue
+		}
+
+		// отрывки проверяются так же, как присланные через API
+		h, e := highlightFromRequest(&api.NewHighlight{
+			Text:          c.highlight.Text,
+			PageStart:     c.highlight.PageStart,
+			PageEnd:       c.highlight.PageEnd,
+			LocationStart: c.highlight.LocationStart,
+			LocationEnd:   c.highlight.LocationEnd,
+		})
+		var p *problem
+		if errors.As(e, &p) {
+			rowError(report, c.row, "%s", p)
+			continue
+		} else if e != nil {
+			return nil, e
+		}
+		h.Added = c.highlight.Added
+
+		title, author := cleanText(c.title), cleanText(c.author)
+		key := titleKey(title)
+		id, ok := shelf[key]
+		if !ok {
+			if title == "" || author == "" {
+				rowError(report, c.row, "title and author of the book are required to add %q", title)
+				continue
+			}
+			id = derivedBookID(title, author)
+			if _, taken := s.users[params.UserID][id]; taken {
+				rowError(report, c.row, "book id %d for %q is already taken", id, title)
+				continue
+			}
+			// отрывки не значат, что книга дочитана, поэтому она считается читаемой
+			book, e := bookFromRequest(&api.NewBook{
+				ID:     id,
+				Title:  api.NewOptString(title),
+				Author: api.NewOptString(author),
+				Status: api.NewOptReadingStatus(api.ReadingStatusReading),
+			})
+			if errors.As(e, &p) {
+				rowError(report, c.row, "%s", p)
+				continue
+			} else if e != nil {
+				return nil, e
+			}
+			shelf[key] = id
+			newBooks = append(newBooks, book)
+			report.Added++
+		}
+
+		var known []api.Highlight
+		if a := s.annotations[params.UserID][id]; a != nil {
+			known = a.highlights
+		}
+		if slices.ContainsFunc(known, func(k api.Highlight) bool { return sameHighlight(k, h) }) ||
+			slices.ContainsFunc(highlights[id], func(k api.Highlight) bool { return sameHighlight(k, h) }) {
+			report.Skipped++
+			continue
+		}
+		highlights[id] = append(highlights[id], h)
+		imported++
+	}
+	report.Highlights = api.NewOptInt(imported)
+
+	if !report.DryRun {
+		for _, book := range newBooks {
+			s.putBook(params.UserID, book)
+		}
+		for id, hs := range highlights {
+			s.addHighlights(params.UserID, id, hs...)
+		}
+	}
+	slices.SortStableFunc(report.Errors, func(a, b api.ImportRowError) int {
+		return cmp.Compare(a.Row, b.Row)
+	})
+	return report, nil
+}
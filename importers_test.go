@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	api "mws/gen_api"
+)
+
+func openFixture(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, e := os.Open("testdata/" + name)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReadGoodreadsRows(t *testing.T) {
+	report := &api.ImportReport{}
+	rows, e := readGoodreadsRows(openFixture(t, "goodreads_library_export.csv"), report)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if len(report.Errors) != 0 {
+		t.Errorf("errors = %+v", report.Errors)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(rows))
+	}
+
+	hunger := rows[0].book
+	if rows[0].row != 1 || hunger.ID != 2767052 || hunger.Title.Value != "The Hunger Games (The Hunger Games, #1)" || hunger.Author.Value != "Suzanne Collins" {
+		t.Errorf("row 1 = %d %+v", rows[0].row, hunger)
+	}
+	if hunger.Isbn10.Value != "0439023483" || hunger.Isbn13.Value != "9780439023481" {
+		t.Errorf("ISBN = %q %q, want unwrapped values", hunger.Isbn10.Value, hunger.Isbn13.Value)
+	}
+	if hunger.Status.Value != api.ReadingStatusRead || hunger.Pages.Value != 374 || hunger.Page.Value != 374 {
+		t.Errorf("status %s, pages %v, page %v, want read book on the last page", hunger.Status.Value, hunger.Pages, hunger.Page)
+	}
+	if want := time.Date(2016, time.May, 15, 0, 0, 0, 0, time.UTC); !hunger.Finished.Value.Equal(want) {
+		t.Errorf("finished = %v, want %v", hunger.Finished, want)
+	}
+
+	hobbit := rows[1].book
+	if hobbit.Status.Value != api.ReadingStatusWantToRead || hobbit.Page.Set || hobbit.Finished.Set {
+		t.Errorf("to-read book = %+v", hobbit)
+	}
+	// to-read из Bookshelves - статус, а не полка
+	if len(rows[1].shelves) != 0 {
+		t.Errorf("to-read book shelves = %v", rows[1].shelves)
+	}
+	// год первой публикации важнее года издания
+	if hobbit.Published.Value.Year() != 1937 {
+		t.Errorf("published = %v, want 1937", hobbit.Published)
+	}
+
+	hitchhiker := rows[2].book
+	if hitchhiker.Status.Value != api.ReadingStatusReading || hitchhiker.Isbn10.Set || hitchhiker.Isbn13.Set || hitchhiker.Pages.Set {
+		t.Errorf("book without ISBN and pages = %+v", hitchhiker)
+	}
+
+	// полка abandoned - своя exclusive shelf пользователя, у нас такого статуса нет
+	thrones := rows[3]
+	if thrones.book.Status.Value != api.ReadingStatusWantToRead || !slices.Equal(thrones.shelves, []string{"abandoned"}) {
+		t.Errorf("book on abandoned shelf: status %s, shelves %v", thrones.book.Status.Value, thrones.shelves)
+	}
+
+	dracula := rows[4].book
+	if rows[4].row != 5 {
+		t.Errorf("Dracula row = %d, want 5", rows[4].row)
+	}
+	if !slices.Equal(rows[4].shelves, []string{"classics", "horror"}) {
+		t.Errorf("Dracula shelves = %v", rows[4].shelves)
+	}
+	var names []string
+	for _, c := range dracula.Contributors {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, "|"); got != "Bram Stoker|Nina Auerbach|David J. Skal" {
+		t.Errorf("contributors = %s", got)
+	}
+}
+
+func TestImportGoodreadsShelves(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	ctx := context.Background()
+	// полка Horror уже есть, регистр в имени не важен
+	if _, e := s.AddUserShelf(ctx, &api.NewShelf{Name: "Horror"}, api.AddUserShelfParams{UserID: 1}); e != nil {
+		t.Fatal(e)
+	}
+
+	report, e := s.ImportGoodreads(ctx, api.ImportGoodreadsReq{Data: openFixture(t, "goodreads_library_export.csv")},
+		api.ImportGoodreadsParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if report.Added != 5 || len(report.Errors) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	shelves, _ := s.GetUserShelves(ctx, api.GetUserShelvesParams{UserID: 1})
+	var names []string
+	for _, sh := range shelves {
+		names = append(names, fmt.Sprintf("%s:%d", sh.Name, sh.Books))
+	}
+	if got := strings.Join(names, " "); got != "abandoned:1 classics:1 Horror:1" {
+		t.Errorf("shelves = %s", got)
+	}
+	if got := s.bookShelves(1, 17245); !slices.Equal(got, []string{"classics", "Horror"}) {
+		t.Errorf("Dracula is on %v", got)
+	}
+
+	long := strings.Repeat("x", 65)
+	report, e = s.ImportGoodreads(ctx, api.ImportGoodreadsReq{Data: strings.NewReader(
+		"Book Id,Title,Author,Bookshelves,Exclusive Shelf\n1,Title,Author," + long + ",read\n")},
+		api.ImportGoodreadsParams{UserID: 2, OnConflict: api.NewOptConflictPolicy(api.ConflictPolicySkip)})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(report.Errors) != 1 || !strings.Contains(report.Errors[0].Message, long) {
+		t.Errorf("errors = %+v, want too long shelf name", report.Errors)
+	}
+}
+
+func TestReadGoodreadsRowsNotExport(t *testing.T) {
+	_, e := readGoodreadsRows(strings.NewReader("id,title,author\n1,a,b\n"), &api.ImportReport{})
+	if e == nil || !strings.Contains(e.Error(), "not a Goodreads export") {
+		t.Errorf("error = %v", e)
+	}
+}
+
+func TestParseClippingMeta(t *testing.T) {
+	for _, tt := range []struct {
+		meta             string
+		kind             clippingKind
+		page             int
+		locStart, locEnd int
+		added            time.Time
+		wantErr          bool
+	}{
+		{
+			meta: "- Your Highlight on page 12 | Location 170-172 | Added on Sunday, 15 May 2016 20:33:15",
+			kind: clippingHighlight, page: 12, locStart: 170, locEnd: 172,
+			added: time.Date(2016, time.May, 15, 20, 33, 15, 0, time.UTC),
+		},
+		{
+			meta: "- Your Highlight on Location 1011-1013 | Added on Tuesday, October 31, 2017 9:05:12 PM",
+			kind: clippingHighlight, locStart: 1011, locEnd: 1013,
+			added: time.Date(2017, time.October, 31, 21, 5, 12, 0, time.UTC),
+		},
+		{
+			meta: "- Your Note on page 12 | Location 172 | Added on Sunday, 15 May 2016 20:34:02",
+			kind: clippingNote, page: 12, locStart: 172, locEnd: 172,
+			added: time.Date(2016, time.May, 15, 20, 34, 2, 0, time.UTC),
+		},
+		{
+			meta: "- Your Bookmark on page 30 | Location 455 | Added on Monday, 16 May 2016 08:01:44",
+			kind: clippingBookmark, page: 30, locStart: 455, locEnd: 455,
+			added: time.Date(2016, time.May, 16, 8, 1, 44, 0, time.UTC),
+		},
+		{
+			meta: "- Ваш выделенный отрывок на странице 15 | местоположение 220-223 | Добавлено: воскресенье, 15 мая 2016 г. в 20:33:15",
+			kind: clippingHighlight, page: 15, locStart: 220, locEnd: 223,
+			added: time.Date(2016, time.May, 15, 20, 33, 15, 0, time.UTC),
+		},
+		{
+			meta: "- Ваш выделенный отрывок в месте 1406-1407 | Добавлено: пятница, 10 апреля 2020 г. в 22:37:43",
+			kind: clippingHighlight, locStart: 1406, locEnd: 1407,
+			added: time.Date(2020, time.April, 10, 22, 37, 43, 0, time.UTC),
+		},
+		{
+			meta: "- Ваша заметка на странице 15 | местоположение 223 | Добавлено: воскресенье, 15 мая 2016 г. в 20:35:40",
+			kind: clippingNote, page: 15, locStart: 223, locEnd: 223,
+			added: time.Date(2016, time.May, 15, 20, 35, 40, 0, time.UTC),
+		},
+		{
+			meta: "- Ваша закладка в месте 1500 | Добавлено: суббота, 11 апреля 2020 г. в 9:02:05",
+			kind: clippingBookmark, locStart: 1500, locEnd: 1500,
+			added: time.Date(2020, time.April, 11, 9, 2, 5, 0, time.UTC),
+		},
+		{meta: "- Your Clip on page 1", wantErr: true},
+	} {
+		t.Run(tt.meta, func(t *testing.T) {
+			var c clipping
+			e := parseClippingMeta(tt.meta, &c)
+			if tt.wantErr {
+				if e == nil {
+					t.Fatal("no error")
+				}
+				return
+			}
+			if e != nil {
+				t.Fatal(e)
+			}
+			h := c.highlight
+			if c.kind != tt.kind {
+				t.Errorf("kind = %d, want %d", c.kind, tt.kind)
+			}
+			if h.PageStart.Or(0) != tt.page || h.PageEnd.Or(0) != tt.page {
+				t.Errorf("pages = %v-%v, want %d", h.PageStart, h.PageEnd, tt.page)
+			}
+			if h.LocationStart.Or(0) != tt.locStart || h.LocationEnd.Or(0) != tt.locEnd {
+				t.Errorf("locations = %v-%v, want %d-%d", h.LocationStart, h.LocationEnd, tt.locStart, tt.locEnd)
+			}
+			if !h.Added.Value.Equal(tt.added) {
+				t.Errorf("added = %v, want %v", h.Added, tt.added)
+			}
+		})
+	}
+}
+
+func TestParseClippings(t *testing.T) {
+	type want struct {
+		row           int
+		title, author string
+		kind          clippingKind
+		text          string
+	}
+	for _, tt := range []struct {
+		file   string
+		want   []want
+		errors []int
+	}{
+		{
+			file: "My Clippings.txt",
+			want: []want{
+				{1, "The Hunger Games", "Suzanne Collins", clippingHighlight, "It is the only way to make sure that I will not be chosen."},
+				{2, "The Hunger Games", "Suzanne Collins", clippingNote, "reread this"},
+				{3, "The Hunger Games", "Suzanne Collins", clippingBookmark, ""},
+				{4, "Dracula", "Bram Stoker", clippingHighlight, "Listen to them, the children of the night.\nWhat music they make!"},
+			},
+			// отрывок без текста
+			errors: []int{5},
+		},
+		{
+			file: "My Clippings.ru.txt",
+			want: []want{
+				{1, "Доктор Живаго", "Пастернак Борис", clippingHighlight, "Человек рождается жить, а не готовиться к жизни."},
+				{2, "Доктор Живаго", "Пастернак Борис", clippingNote, "перечитать"},
+				{3, "Мастер и Маргарита", "Булгаков Михаил", clippingHighlight, "Никогда и ничего не просите!"},
+				{4, "Мастер и Маргарита", "Булгаков Михаил", clippingBookmark, ""},
+			},
+		},
+	} {
+		t.Run(tt.file, func(t *testing.T) {
+			report := &api.ImportReport{}
+			clippings, e := parseClippings(openFixture(t, tt.file), report)
+			if e != nil {
+				t.Fatal(e)
+			}
+			var errorRows []int
+			for _, re := range report.Errors {
+				errorRows = append(errorRows, re.Row)
+			}
+			if len(errorRows) != len(tt.errors) || len(errorRows) > 0 && errorRows[0] != tt.errors[0] {
+				t.Errorf("error rows = %v, want %v", errorRows, tt.errors)
+			}
+			if len(clippings) != len(tt.want) {
+				t.Fatalf("got %d clippings, want %d", len(clippings), len(tt.want))
+			}
+			for i, w := range tt.want {
+				c := clippings[i]
+				if c.row != w.row || c.title != w.title || c.author != w.author || c.kind != w.kind || c.highlight.Text != w.text {
+					t.Errorf("clipping %d = %d %q %q %d %q, want %+v", i, c.row, c.title, c.author, c.kind, c.highlight.Text, w)
+				}
+				if !c.highlight.Added.Set {
+					t.Errorf("clipping %d has no date", i)
+				}
+			}
+		})
+	}
+}
+
+// failingReader отдает ошибку чтения посреди файла
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestImportKindleClippings(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	ctx := context.Background()
+
+	report, e := s.ImportKindleClippings(ctx, api.ImportKindleClippingsReq{Data: openFixture(t, "My Clippings.txt")},
+		api.ImportKindleClippingsParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if report.Added != 2 || report.Highlights.Value != 2 || report.Skipped != 2 {
+		t.Errorf("report = %+v, want 2 books and 2 highlights added, note and bookmark skipped", report)
+	}
+	// отрывки не значат, что книга дочитана
+	for _, book := range s.users[1] {
+		if book.Status != api.ReadingStatusReading || len(book.Reads) != 1 || book.Reads[0].Completed {
+			t.Errorf("book %q: status %s, reads %+v, want one open read-through", book.Title, book.Status, book.Reads)
+		}
+	}
+
+	// повторный импорт того же файла ничего не добавляет
+	report, e = s.ImportKindleClippings(ctx, api.ImportKindleClippingsReq{Data: openFixture(t, "My Clippings.txt")},
+		api.ImportKindleClippingsParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if report.Added != 0 || report.Highlights.Value != 0 {
+		t.Errorf("second import report = %+v", report)
+	}
+
+	_, e = s.ImportKindleClippings(ctx, api.ImportKindleClippingsReq{Data: failingReader{}}, api.ImportKindleClippingsParams{UserID: 1})
+	var p *problem
+	if !errors.As(e, &p) || p.code != api.ErrorCodeInvalidRequest {
+		t.Errorf("error = %v, want invalid_request", e)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// normalizeISBN убирает из ISBN дефисы и пробелы, которыми его обычно разбивают на группы
+func normalizeISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isbn10Check считает контрольный символ по первым 9 цифрам ISBN-10
+func isbn10Check(digits string) byte {
+	sum := 0
+	for i := range 9 {
+		sum += (10 - i) * int(digits[i]-'0')
+	}
+	switch check := (11 - sum%11) % 11; check {
+	case 10:
+		return 'X'
+	default:
+		return byte('0' + check)
+	}
+}
+
+// isbn13Check считает контрольную цифру по первым 12 цифрам ISBN-13
+func isbn13Check(digits string) byte {
+	sum := 0
+	for i := range 12 {
+		if d := int(digits[i] - '0'); i%2 == 0 {
+			sum += d
+		} else {
+			sum += 3 * d
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func validISBN10(isbn string) bool {
+	return len(isbn) == 10 && isDigits(isbn[:9]) && isbn10Check(isbn) == isbn[9]
+}
+
+func validISBN13(isbn string) bool {
+	return len(isbn) == 13 && isDigits(isbn) && isbn13Check(isbn) == isbn[12]
+}
+
+// isbn10To13 переводит валидный ISBN-10 в ISBN-13 с префиксом 978
+func isbn10To13(isbn string) string {
+	digits := "978" + isbn[:9]
+	return digits + string(isbn13Check(digits))
+}
+
+// isbn13To10 переводит валидный ISBN-13 в ISBN-10, что возможно только для префикса 978
+func isbn13To10(isbn string) (string, bool) {
+	if !strings.HasPrefix(isbn, "978") {
+		return "", false
+	}
+	digits := isbn[3:12]
+	return digits + string(isbn10Check(digits)), true
+}
+
+// resolveISBN проверяет переданные ISBN и дополняет недостающий, если один можно получить из другого.
+// Пустые строки означают что ISBN не задан
+func resolveISBN(isbn10, isbn13 string) (string, string, error) {
+	isbn10, isbn13 = normalizeISBN(isbn10), normalizeISBN(isbn13)
+	if isbn10 != "" && !validISBN10(isbn10) {
+		return "", "", fmt.Errorf("invalid ISBN-10 %q", isbn10)
+	}
+	if isbn13 != "" && !validISBN13(isbn13) {
+		return "", "", fmt.Errorf("invalid ISBN-13 %q", isbn13)
+	}
+
+	switch {
+	case isbn10 != "" && isbn13 == "":
+		isbn13 = isbn10To13(isbn10)
+	case isbn10 == "" && isbn13 != "":
+		isbn10, _ = isbn13To10(isbn13)
+	case isbn10 != "" && isbn13 != "":
+		if isbn10To13(isbn10) != isbn13 {
+			return "", "", fmt.Errorf("ISBN-10 %s and ISBN-13 %s belong to different books", isbn10, isbn13)
+		}
+	}
+	return isbn10, isbn13, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/text/language"
+
+	api "mws/gen_api"
+)
+
+// defaultLanguage - язык исходных текстов, на нем отвечаем, если других предпочтений нет
+var defaultLanguage = language.English
+
+// languages - языки, на которые есть перевод
+var languages = []language.Tag{language.English, language.Russian}
+
+var languageMatcher = language.NewMatcher(languages)
+
+// requestLanguage выбирает язык ответа по Accept-Language с учетом весов. Если ни один из перечисленных
+// языков не поддерживается или заголовка нет, используется fallback
+func requestLanguage(r *http.Request, fallback language.Tag) language.Tag {
+	accepted, weights, e := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if e != nil {
+		return fallback
+	}
+	// q=0 означает, что язык не подходит
+	preferred := accepted[:0]
+	for i, tag := range accepted {
+		if weights[i] > 0 {
+			preferred = append(preferred, tag)
+		}
+	}
+	if len(preferred) == 0 {
+		return fallback
+	}
+	_, i, confidence := languageMatcher.Match(preferred...)
+	if confidence == language.No {
+		return fallback
+	}
+	return languages[i]
+}
+
+// userLanguage возвращает язык, который выбрал пользователь
+func (s *serviceImpl) userLanguage(userID int) language.Tag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if lang, ok := s.locales[userID]; ok {
+		return language.Make(string(lang))
+	}
+	return defaultLanguage
+}
+
+func (s *serviceImpl) GetLocale(ctx context.Context, params api.GetLocaleParams) (*api.Locale, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lang, ok := s.locales[params.UserID]
+	if !ok {
+		lang = api.LanguageEn
+	}
+	return &api.Locale{Language: lang}, nil
+}
+
+func (s *serviceImpl) SetLocale(ctx context.Context, req *api.Locale, params api.SetLocaleParams) (*api.Locale, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locales[params.UserID] = req.Language
+	return req, nil
+}
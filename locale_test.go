@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	api "mws/gen_api"
+)
+
+func TestRequestLanguage(t *testing.T) {
+	for _, tt := range []struct {
+		header   string
+		fallback language.Tag
+		want     language.Tag
+	}{
+		{"ru;q=0.9,en;q=0.8", language.English, language.Russian},
+		{"en;q=0.1,ru", language.English, language.Russian},
+		{"ru-RU,ru;q=0.9", language.English, language.Russian},
+		{"en-GB", language.Russian, language.English},
+		{"", language.Russian, language.Russian},
+		{"", language.English, language.English},
+		// неподдерживаемый язык не мешает выбрать следующий по весу
+		{"de,ru;q=0.5", language.English, language.Russian},
+		{"de", language.English, language.English},
+		{"de, fr;q=0.5", language.Russian, language.Russian},
+		// q=0 запрещает язык
+		{"ru;q=0", language.English, language.English},
+		{"ru;q=0,en;q=0", language.Russian, language.Russian},
+		{"not a language tag;;", language.Russian, language.Russian},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set("Accept-Language", tt.header)
+		}
+		if got := requestLanguage(r, tt.fallback); got != tt.want {
+			t.Errorf("Accept-Language %q, fallback %s: got %s, want %s", tt.header, tt.fallback, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizeErrors(t *testing.T) {
+	service := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	server, e := api.NewServer(service,
+		api.WithMiddleware(service.localizeErrors),
+		api.WithErrorHandler(handleError),
+		api.WithNotFound(handleNotFound),
+		api.WithMethodNotAllowed(handleMethodNotAllowed),
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	ctx := context.Background()
+	for _, userID := range []int{1, 2} {
+		if _, e := service.AddUserBook(ctx, &api.NewBook{ID: 1, Title: api.NewOptString("Dracula"), Author: api.NewOptString("Bram Stoker")},
+			api.AddUserBookParams{UserID: userID}); e != nil {
+			t.Fatal(e)
+		}
+	}
+	// пользователь 2 выбрал русский
+	if _, e := service.SetLocale(ctx, &api.Locale{Language: api.LanguageRu}, api.SetLocaleParams{UserID: 2}); e != nil {
+		t.Fatal(e)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		userID int
+		header string
+		title  string
+		detail string
+	}{
+		{"english by default", 1, "", "Book not found", "book 999 not found for user 1"},
+		{"user's locale without header", 2, "", "Книга не найдена", "у пользователя 2 нет книги 999"},
+		{"header over user's locale", 2, "en", "Book not found", "book 999 not found for user 2"},
+		{"weights", 1, "ru;q=0.9,en;q=0.8", "Книга не найдена", "у пользователя 1 нет книги 999"},
+		{"higher weight later", 1, "en;q=0.1,ru", "Книга не найдена", "у пользователя 1 нет книги 999"},
+		{"unsupported language", 1, "de", "Book not found", "book 999 not found for user 1"},
+		{"unsupported language with user's locale", 2, "de", "Книга не найдена", "у пользователя 2 нет книги 999"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			url := "/users/" + strconv.Itoa(tt.userID) + "/books/999"
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, r)
+
+			var res struct {
+				Title    string        `json:"title"`
+				Detail   string        `json:"detail"`
+				Code     api.ErrorCode `json:"code"`
+				Instance string        `json:"instance"`
+			}
+			if e := json.Unmarshal(w.Body.Bytes(), &res); e != nil {
+				t.Fatalf("%v: %s", e, w.Body)
+			}
+			if w.Code != http.StatusNotFound || res.Code != api.ErrorCodeBookNotFound || res.Instance != url {
+				t.Errorf("status %d, code %s, instance %s", w.Code, res.Code, res.Instance)
+			}
+			if res.Title != tt.title || res.Detail != tt.detail {
+				t.Errorf("got %q: %q, want %q: %q", res.Title, res.Detail, tt.title, tt.detail)
+			}
+		})
+	}
+}
+
+// verbRe находит глаголы форматирования вместе с явными номерами аргументов
+var verbRe = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?[a-zA-Z]`)
+
+// maxArg возвращает наибольший номер аргумента, который использует формат
+func maxArg(format string) int {
+	n, last := 0, 0
+	for _, m := range verbRe.FindAllStringSubmatch(format, -1) {
+		last++
+		if m[1] != "" {
+			last, _ = strconv.Atoi(m[1])
+		}
+		n = max(n, last)
+	}
+	return n
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for _, code := range api.ErrorCode("").AllValues() {
+		if _, ok := statuses[code]; !ok {
+			t.Errorf("%s has no HTTP status", code)
+		}
+		for _, lang := range languages {
+			if m, ok := catalog[code][lang]; !ok || m.title == "" {
+				t.Errorf("%s has no title in %s", code, lang)
+			}
+		}
+		// перевод может пропустить аргумент, но не может сослаться на несуществующий
+		for format, translated := range catalog[code][language.Russian].details {
+			if maxArg(translated) > maxArg(format) {
+				t.Errorf("%s: translation %q uses more arguments than %q", code, translated, format)
+			}
+		}
+	}
+}
+
+// formatOnlyRe - формат, в котором нечего переводить: только подстановки и знаки препинания
+var formatOnlyRe = regexp.MustCompile(`^(%[a-z]|[\s:;,.-])*$`)
+
+// TestAllMessagesTranslated ищет в исходниках вызовы err, invalid, invalidField и checks.add
+// и проверяет, что у каждого формата есть русский перевод
+func TestAllMessagesTranslated(t *testing.T) {
+	names := make(map[string]api.ErrorCode)
+	for _, code := range api.ErrorCode("").AllValues() {
+		name := "ErrorCode"
+		for _, part := range strings.Split(string(code), "_") {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+		names[name] = code
+	}
+
+	files, e := filepath.Glob("*.go")
+	if e != nil {
+		t.Fatal(e)
+	}
+	fset := token.NewFileSet()
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, e := parser.ParseFile(fset, file, nil, 0)
+		if e != nil {
+			t.Fatal(e)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			// код, который вычисляется при вызове, подходит любой
+			codes, formatArg := []api.ErrorCode{api.ErrorCodeInvalidRequest}, -1
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				switch fun.Name {
+				case "err":
+					formatArg = 1
+					codes = api.ErrorCode("").AllValues()
+					if sel, ok := call.Args[0].(*ast.SelectorExpr); ok {
+						codes = []api.ErrorCode{names[sel.Sel.Name]}
+					}
+				case "invalid":
+					formatArg = 0
+				case "invalidField":
+					formatArg = 1
+				}
+			case *ast.SelectorExpr:
+				if fun.Sel.Name == "add" && len(call.Args) >= 2 {
+					formatArg = 1
+				}
+			}
+			if formatArg < 0 || formatArg >= len(call.Args) {
+				return true
+			}
+			lit, ok := call.Args[formatArg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			format, _ := strconv.Unquote(lit.Value)
+			found++
+			if formatOnlyRe.MatchString(format) {
+				return true
+			}
+			if !slices.ContainsFunc(codes, func(code api.ErrorCode) bool {
+				_, ok := catalog[code][language.Russian].details[format]
+				return ok
+			}) {
+				t.Errorf("%s: %s %q has no Russian translation", fset.Position(call.Pos()), codes, format)
+			}
+			return true
+		})
+	}
+	if found == 0 {
+		t.Fatal("no messages found")
+	}
+}
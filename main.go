@@ -2,10 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"sync"
+	"time"
+
+	"github.com/ogen-go/ogen/middleware"
 
 	api "mws/gen_api"
 )
@@ -19,18 +26,83 @@ type serviceImpl struct {
 	// здесь они хранятся в более-менее непрерывном участке памяти, так как при переаллокации мапы
 	// они все будут лежать в выделенном протяженном участке
 	users map[int]map[int]api.Book
-}
 
-func newServiceImpl() *serviceImpl {
-	return &serviceImpl{
-		users: make(map[int]map[int]api.Book),
-	}
+	index *searchIndex
+
+	// справочник авторов общий для всех пользователей
+	authors *authorRegistry
+
+	// userID -> bookID -> заметки, выделенные отрывки и закладки
+	annotations map[int]map[int]*annotations
+	// id у заметок всех видов общие
+	lastAnnotationID int
+
+	// bookID -> userID -> отзыв. Книги с одним id у разных пользователей считаются одной книгой из общего каталога
+	reviews map[int]map[int]api.Review
+	// bookID -> оценки всех пользователей
+	ratings map[int]*ratingStats
+
+	// userID -> прочитанные страницы по времени, из них считается статистика
+	pageLog map[int][]pageEvent
+
+	// userID -> bookID -> план дочитать книгу к сроку
+	plans map[int]map[int]*readingPlan
+
+	// сколько пользователей читают каждую книгу и пару книг, для рекомендаций
+	coReading *coReading
+
+	// follower -> на кого подписан и userID -> подписчики
+	following map[int]map[int]struct{}
+	followers map[int]map[int]struct{}
+	// userID -> кому видна активность, по умолчанию подписчикам
+	privacy map[int]api.ActivityVisibility
+	// userID -> язык сообщений об ошибках, если запрос не указал свой
+	locales map[int]api.Language
+	// userID -> последние события тех, на кого он подписан, по возрастанию id
+	feeds          map[int][]api.Activity
+	lastActivityID int
+
+	// clubID -> клуб
+	clubs      map[int]*club
+	lastClubID int
+
+	// userID -> bookID -> ключ порядка в очереди книг, которые пользователь хочет прочитать
+	queue map[int]map[int]string
+
+	// userID -> shelfID -> полка
+	shelves     map[int]map[int]*shelf
+	lastShelfID int
+
+	// userID -> bookID -> обложка
+	covers map[int]map[int]cover
+	blobs  blobStore
+
+	// может быть nil, если каталог не подключен
+	catalog CatalogProvider
 }
 
-func err(code int, format string, args ...any) *api.Error {
-	return &api.Error{
-		StatusCode: code,
-		Message:    fmt.Sprintf(format, args...),
+func newServiceImpl(catalog CatalogProvider, blobs blobStore) *serviceImpl {
+	return &serviceImpl{
+		users:       make(map[int]map[int]api.Book),
+		index:       newSearchIndex(),
+		authors:     newAuthorRegistry(),
+		annotations: make(map[int]map[int]*annotations),
+		reviews:     make(map[int]map[int]api.Review),
+		ratings:     make(map[int]*ratingStats),
+		pageLog:     make(map[int][]pageEvent),
+		plans:       make(map[int]map[int]*readingPlan),
+		coReading:   newCoReading(),
+		following:   make(map[int]map[int]struct{}),
+		followers:   make(map[int]map[int]struct{}),
+		privacy:     make(map[int]api.ActivityVisibility),
+		locales:     make(map[int]api.Language),
+		feeds:       make(map[int][]api.Activity),
+		clubs:       make(map[int]*club),
+		queue:       make(map[int]map[int]string),
+		shelves:     make(map[int]map[int]*shelf),
+		covers:      make(map[int]map[int]cover),
+		blobs:       blobs,
+		catalog:     catalog,
 	}
 }
 
@@ -39,9 +111,19 @@ func (s *serviceImpl) GetUserBooks(ctx context.Context, params api.GetUserBooksP
 	defer s.mu.RUnlock()
 
 	if books, ok := s.users[params.UserID]; ok {
+		var onShelf map[int]struct{}
+		if name, ok := params.Shelf.Get(); ok {
+			onShelf = make(map[int]struct{})
+			if id := s.findShelf(params.UserID, name); id != 0 {
+				onShelf = s.shelves[params.UserID][id].books
+			}
+		}
+
 		values := make([]api.Book, 0, len(books))
-		for _, book := range books {
-			values = append(values, book)
+		for id, book := range books {
+			if _, ok := onShelf[id]; onShelf == nil || ok {
+				values = append(values, s.present(params.UserID, book))
+			}
 		}
 		return values, nil
 	} else { // у пользователя нет книг, либо по хорошему надо отдельно проверять есть ли такой пользователь
@@ -49,61 +131,243 @@ func (s *serviceImpl) GetUserBooks(ctx context.Context, params api.GetUserBooksP
 	}
 }
 
-func (s *serviceImpl) AddUserBook(ctx context.Context, req *api.Book, params api.AddUserBookParams) (api.AddUserBookRes, error) {
+// present дополняет книгу тем, что хранится отдельно от полки: оценками всех пользователей
+// и полками, на которых стоит книга, а также считает прогресс чтения. Вызывается под s.mu
+func (s *serviceImpl) present(userID int, book api.Book) api.Book {
+	book.Ratings = api.NewOptRatingSummary(s.ratingSummary(book.ID))
+	book.Shelves = s.bookShelves(userID, book.ID)
+	unit, _, _ := bookUnit(&book)
+	book.Unit = api.NewOptProgressUnit(unit)
+	if read, ok := progress(&book); ok {
+		book.Progress = api.NewOptFloat64(read)
+	}
+	return book
+}
+
+// bookFromRequest собирает книгу из запроса, проверяя ее и дополняя ISBN
+func bookFromRequest(req *api.NewBook) (api.Book, error) {
+	if e := checkNewBook(req); e != nil {
+		return api.Book{}, e
+	}
+	isbn10, isbn13, e := resolveISBN(req.Isbn10.Or(""), req.Isbn13.Or(""))
+	if e != nil {
+		return api.Book{}, invalid("%s", e)
+	}
+
+	book := api.Book{
+		ID:        req.ID,
+		Page:      req.Page.Or(1),
+		Title:     req.Title.Or(""),
+		Author:    req.Author.Or(""),
+		Published: req.Published,
+		Pages:     req.Pages,
+		Language:  req.Language,
+		Status:    req.Status.Or(api.ReadingStatusReading),
+		Finished:  req.Finished,
+		Series:    req.Series,
+		Editions:  req.Editions,
+		Edition:   req.Edition,
+	}
+	contributorsFromRequest(&book, req.Contributors)
+	if e := checkEditions(&book); e != nil {
+		return api.Book{}, e
+	}
+	if len(req.Reads) > 0 {
+		if e := applyReads(&book, req.Reads); e != nil {
+			return api.Book{}, e
+		}
+	} else {
+		initialReads(&book)
+	}
+	if isbn10 != "" {
+		book.Isbn10 = api.NewOptString(isbn10)
+	}
+	if isbn13 != "" {
+		book.Isbn13 = api.NewOptString(isbn13)
+	}
+	return book, nil
+}
+
+func incomplete(book *api.Book) bool {
+	return book.Title == "" || book.Author == ""
+}
+
+// fillFromCatalog дополняет незаполненные поля книги сведениями из каталога
+func (s *serviceImpl) fillFromCatalog(ctx context.Context, book *api.Book) error {
+	if s.catalog == nil || !book.Isbn13.Set ||
+		!incomplete(book) && book.Published.Set && book.Series.Set && len(book.Editions) > 0 {
+		return nil
+	}
+
+	entry, e := s.catalog.Lookup(ctx, book.Isbn13.Value)
+	if errors.Is(e, errNotInCatalog) {
+		return nil
+	} else if e != nil {
+		return e
+	}
+	if book.Title == "" {
+		book.Title = entry.Title
+	}
+	if book.Author == "" {
+		book.Author = entry.Author
+	}
+	if !book.Published.Set {
+		book.Published = api.NewOptDate(entry.Published)
+	}
+	if !book.Series.Set && entry.Series != "" {
+		series := api.Series{Name: entry.Series}
+		if entry.SeriesNumber != 0 {
+			series.Number = api.NewOptFloat64(entry.SeriesNumber)
+		}
+		book.Series = api.NewOptSeries(series)
+	}
+	if !book.Pages.Set && entry.Pages != 0 {
+		book.Pages = api.NewOptInt(entry.Pages)
+	}
+	// запись каталога описывает одно издание - то, чей ISBN указан
+	if len(book.Editions) == 0 && entry.Format != "" {
+		edition := api.Edition{ID: 1, Format: entry.Format, Isbn13: book.Isbn13}
+		if entry.Pages != 0 && editionUnit(&edition) == api.ProgressUnitPage {
+			edition.Length = api.NewOptInt(entry.Pages)
+		}
+		book.Editions = []api.Edition{edition}
+		book.Edition = api.NewOptInt(edition.ID)
+	}
+	return nil
+}
+
+// prepareBook превращает запрос на добавление в готовую к добавлению книгу.
+// Ошибки в самом запросе возвращаются как *problem.
+// В каталог ходит без захвата мьютекса, поиск в нем может быть долгим
+func (s *serviceImpl) prepareBook(ctx context.Context, req *api.NewBook) (api.Book, error) {
+	book, e := bookFromRequest(req)
+	if e != nil {
+		return api.Book{}, e
+	}
+	if e := s.fillFromCatalog(ctx, &book); e != nil {
+		return api.Book{}, e
+	}
+	if incomplete(&book) {
+		return api.Book{}, invalid("title and author of the book %d are required", book.ID)
+	}
+	return book, nil
+}
+
+// putBook кладет книгу на полку пользователя, заменяя такую же, если она там уже есть,
+// и возвращает ее в том виде, в каком она легла на полку. Вызывается под s.mu
+func (s *serviceImpl) putBook(userID int, book api.Book) api.Book {
+	s.linkAuthors(&book)
+
+	books, exists := s.users[userID]
+	if !exists {
+		books = make(map[int]api.Book)
+		s.users[userID] = books
+	}
+	if old, exists := books[book.ID]; exists {
+		s.index.remove(userID, &old)
+	} else {
+		s.coReading.update(book.ID, books, 1)
+	}
+	books[book.ID] = book
+	s.index.add(userID, &book)
+	s.syncQueue(userID, &book)
+	s.syncClubs(userID, book.ID)
+	return book
+}
+
+// addBook - общий путь добавления новой книги на полку для AddUserBook и загрузки файлов книг
+func (s *serviceImpl) addBook(ctx context.Context, userID int, req *api.NewBook) (api.Book, error) {
+	book, e := s.prepareBook(ctx, req)
+	if e != nil {
+		return api.Book{}, e
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.users[params.UserID]; !exists {
-		s.users[params.UserID] = make(map[int]api.Book)
+	if _, exists := s.users[userID][book.ID]; exists {
+		return api.Book{}, err(api.ErrorCodeBookExists, "user %d already has the book with id %d, start a new read-through to reread it", userID, book.ID)
 	}
 
-	if _, exists := s.users[params.UserID][req.ID]; exists {
-		return err(http.StatusConflict, "user %d is already reading the book with id %d", params.UserID, req.ID), nil
-	}
+	book = s.putBook(userID, book)
+	s.publishStatus(userID, "", &book)
+	return s.present(userID, book), nil
+}
 
-	s.users[params.UserID][req.ID] = *req
-	return req, nil
+func (s *serviceImpl) AddUserBook(ctx context.Context, req *api.NewBook, params api.AddUserBookParams) (*api.Book, error) {
+	book, e := s.addBook(ctx, params.UserID, req)
+	if e != nil {
+		return nil, e
+	}
+	return &book, nil
 }
 
-func (s *serviceImpl) GetUserBook(ctx context.Context, params api.GetUserBookParams) (api.GetUserBookRes, error) {
+func (s *serviceImpl) GetUserBook(ctx context.Context, params api.GetUserBookParams) (*api.Book, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if books, ok := s.users[params.UserID]; !ok {
-		return err(http.StatusNotFound, "user %d not found", params.UserID), nil
+		return nil, err(api.ErrorCodeUserNotFound, "user %d not found", params.UserID)
 	} else if book, ok := books[params.BookID]; !ok {
-		return err(http.StatusNotFound, "book %d not found for user %d", params.BookID, params.UserID), nil
+		return nil, err(api.ErrorCodeBookNotFound, "book %d not found for user %d", params.BookID, params.UserID)
 	} else {
+		book = s.present(params.UserID, book)
 		return &book, nil
 	}
 }
 
-func (s *serviceImpl) UpdateReadingProgress(ctx context.Context, req *api.UpdateReadingProgressReq, params api.UpdateReadingProgressParams) (api.UpdateReadingProgressRes, error) {
+func (s *serviceImpl) UpdateReadingProgress(ctx context.Context, req *api.UpdateReadingProgressReq, params api.UpdateReadingProgressParams) (*api.Book, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if books, ok := s.users[params.UserID]; !ok {
-		return err(http.StatusNotFound, "user %d not found", params.UserID), nil
+		return nil, err(api.ErrorCodeUserNotFound, "user %d not found", params.UserID)
 	} else if book, ok := books[params.BookID]; !ok {
-		return err(http.StatusNotFound, "book %d not found for user %d", params.BookID, params.UserID), nil
+		return nil, err(api.ErrorCodeBookNotFound, "book %d not found for user %d", params.BookID, params.UserID)
 	} else {
-		book.Page = req.Page
+		unit, _, _ := bookUnit(&book)
+		position, e := positionFrom(&book, req.Page, req.Unit.Or(unit))
+		if e != nil {
+			return nil, e
+		}
+		before := book
+		// новое прочтение при смене статуса начнется уже с новой страницы
+		setPage(&book, position)
+		if status, ok := req.Status.Get(); ok {
+			setStatus(&book, status)
+		}
 		books[params.BookID] = book
+		s.logPages(params.UserID, &before, &book)
+		s.trackPlan(params.UserID, &before, &book)
+		s.syncClubs(params.UserID, book.ID)
+		s.publishStatus(params.UserID, before.Status, &book)
+		s.syncQueue(params.UserID, &book)
+
+		book = s.present(params.UserID, book)
 		return &book, nil
 	}
 }
 
-func (s *serviceImpl) RemoveUserBook(ctx context.Context, params api.RemoveUserBookParams) (api.RemoveUserBookRes, error) {
+func (s *serviceImpl) RemoveUserBook(ctx context.Context, params api.RemoveUserBookParams) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if books, ok := s.users[params.UserID]; !ok {
-		return err(http.StatusNotFound, "user %d not found", params.UserID), nil
-	} else if _, ok := books[params.BookID]; !ok {
-		return err(http.StatusNotFound, "book %d not found for user %d", params.BookID, params.UserID), nil
+		return err(api.ErrorCodeUserNotFound, "user %d not found", params.UserID)
+	} else if book, ok := books[params.BookID]; !ok {
+		return err(api.ErrorCodeBookNotFound, "book %d not found for user %d", params.BookID, params.UserID)
 	} else {
 		delete(books, params.BookID)
-		return &api.RemoveUserBookNoContent{}, nil
+		s.coReading.update(params.BookID, books, -1)
+		delete(s.annotations[params.UserID], params.BookID)
+		s.dropReview(params.UserID, params.BookID)
+		s.takeOffShelves(params.UserID, params.BookID)
+		delete(s.queue[params.UserID], params.BookID)
+		s.dropPages(params.UserID, params.BookID)
+		delete(s.plans[params.UserID], params.BookID)
+		delete(s.covers[params.UserID], params.BookID)
+		s.index.remove(params.UserID, &book)
+		return nil
 	}
 }
 
@@ -116,14 +380,71 @@ func (s *serviceImpl) RemoveUserBook(ctx context.Context, params api.RemoveUserB
 // 	}
 // }
 
+// logRequests пишет в лог каждый запрос, виден только с уровнем debug
+func logRequests(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+	start := time.Now()
+	resp, err := next(req)
+	slog.Debug("request", "operation", req.OperationID, "method", req.Raw.Method, "path", req.Raw.URL.Path,
+		"duration", time.Since(start), "error", err)
+	return resp, err
+}
+
 func main() {
-	var service api.Handler = newServiceImpl()
+	cfg, printConfig, err := loadConfig(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%s\n", err)
+		os.Exit(2)
+	}
+	if printConfig {
+		if err := cfg.print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	controller, err := api.NewServer(service)
+	level, _ := cfg.logLevel()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	// сообщения через log - только фатальные ошибки запуска, они не должны отсекаться уровнем
+	slog.SetLogLoggerLevel(slog.LevelError)
+
+	var catalog CatalogProvider
+	if cfg.Catalog != "" {
+		c, err := loadFileCatalog(cfg.Catalog)
+		if err != nil {
+			log.Fatal(err)
+		}
+		catalog = c
+	}
+
+	blobs, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := newServiceImpl(catalog, blobs)
+
+	controller, err := api.NewServer(service,
+		api.WithPathPrefix(cfg.PathPrefix),
+		api.WithMiddleware(logRequests, service.localizeErrors),
+		api.WithErrorHandler(handleError),
+		api.WithNotFound(handleNotFound),
+		api.WithMethodNotAllowed(handleMethodNotAllowed),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := http.ListenAndServe(":8080", controller); err != nil {
+	server := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           controller,
+		ReadHeaderTimeout: cfg.Timeouts.ReadHeader,
+		ReadTimeout:       cfg.Timeouts.Read,
+		WriteTimeout:      cfg.Timeouts.Write,
+		IdleTimeout:       cfg.Timeouts.Idle,
+	}
+	slog.Info("listening", "address", cfg.Listen, "path_prefix", cfg.PathPrefix, "storage", cfg.Storage.Backend)
+	if err := server.ListenAndServe(); err != nil {
 		log.Fatal(err)
 	}
 }
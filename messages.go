@@ -0,0 +1,326 @@
+package main
+
+import (
+	"golang.org/x/text/language"
+
+	api "mws/gen_api"
+)
+
+// message - тексты ошибок одного кода на одном языке: заголовок и переводы форматов detail и сообщений полей.
+// Ключ в details - английский формат в том виде, в котором он передается в err. Формат без перевода
+// отдается как есть, так что новая ошибка без перевода просто останется английской
+type message struct {
+	title   string
+	details map[string]string
+}
+
+// catalog - тексты ошибок по кодам. Английские форматы записаны прямо в вызовах err, поэтому у английского
+// только заголовки. В переводах порядок аргументов меняется через %[n]d, лишние аргументы пропускаются так же
+var catalog = map[api.ErrorCode]map[language.Tag]message{
+	api.ErrorCodeInvalidRequest: {
+		language.English: {title: "Invalid request"},
+		language.Russian: {"Неверный запрос", map[string]string{
+			"%s parameter %q is invalid: %s":   "неверное значение параметра %[2]q (%[1]s): %[3]s",
+			"request body is invalid":          "тело запроса не прошло проверку",
+			"request body is not valid %s: %s": "тело запроса не является корректным %s: %s",
+			"request body is required":         "нужно тело запроса",
+
+			"%s must not be blank":                                "поле %s не должно быть пустым",
+			"%s %s is in the future":                              "дата %[1]s %[2]s еще не наступила",
+			"page must be positive, got %d":                       "страница должна быть положительной, получено %d",
+			"location must be positive, got %d":                   "позиция должна быть положительной, получено %d",
+			"either page or location of the bookmark is required": "у закладки должна быть страница или позиция",
+			"%s end is given without start":                       "конец диапазона %s указан без начала",
+			"%s must be positive, got %d":                         "%s: значение должно быть положительным, получено %d",
+			"%s range %d-%d ends before it starts":                "диапазон %s %d-%d заканчивается раньше, чем начинается",
+			"milestones %q and %q are at the same position":       "этапы %q и %q приходятся на одно место",
+
+			"broken image: %s":                         "поврежденное изображение: %s",
+			"image is %s, but Content-Type says %s":    "изображение в формате %s, а в Content-Type указан %s",
+			"image is %dx%d, maximum is %d megapixels": "размер изображения %dx%d, а допустимо не больше %d мегапикселей",
+
+			"book has no edition %d":                           "у книги нет издания %d",
+			"edition %d has length %d":                         "у издания %d длина %d",
+			"edition %d: %s":                                   "издание %d: %s",
+			"edition id %d is not positive or not unique":      "id издания %d не положительный или повторяется",
+			"EPUB file is larger than %d MiB":                  "EPUB-файл больше %d МиБ",
+			"EPUB has no title or author, book id is required": "в EPUB нет названия или автора, нужно указать id книги",
+			"EPUB container has no package document":           "в контейнере EPUB нет документа пакета",
+			"not an EPUB file: %s":                             "это не EPUB-файл: %s",
+			"read EPUB container: %s":                          "не удалось прочитать контейнер EPUB: %s",
+			"read EPUB content %q: %s":                         "не удалось прочитать содержимое EPUB %q: %s",
+			"read EPUB package document: %s":                   "не удалось прочитать документ пакета EPUB: %s",
+			"title and author of the book %d are required":     "у книги %d должны быть название и автор",
+
+			"deadline %s is in the past":          "срок %s уже прошел",
+			"every day of the week is a rest day": "все дни недели отмечены как выходные",
+
+			"%s %d is past the end of the book, its length is %d":             "позиция %[2]d (%[1]s) дальше конца книги, ее длина %[3]d",
+			"%s must be at least %d, got %d":                                  "позиция (%s) должна быть не меньше %d, получено %d",
+			"percent must be from 0 to 100, got %d":                           "процент должен быть от 0 до 100, получено %d",
+			"length of the book is unknown, percent can't be converted to %s": "длина книги неизвестна, процент нельзя перевести в %s",
+			"progress of the book is measured in %s, not in %s":               "прогресс книги измеряется в %s, а не в %s",
+
+			"only the last read-through may be not completed":         "незавершенным может быть только последнее прочтение",
+			"page of read-through %d must be positive, got %d":        "страница прочтения %d должна быть положительной, получено %d",
+			"read-through %d has finish date but is not completed":    "у прочтения %d есть дата окончания, но оно не завершено",
+			"read-through %d is finished before it is started":        "прочтение %d закончено раньше, чем начато",
+			"rating must be from 1 to 5 with half-star steps, got %v": "оценка должна быть от 1 до 5 с шагом в половину звезды, получено %v",
+		}},
+	},
+	api.ErrorCodeUnknownTimeZone: {
+		language.English: {title: "Unknown time zone"},
+		language.Russian: {"Неизвестный часовой пояс", map[string]string{
+			"unknown time zone %q": "неизвестный часовой пояс %q",
+		}},
+	},
+	api.ErrorCodeInvalidCursor: {
+		language.English: {title: "Invalid cursor"},
+		language.Russian: {"Неверный курсор", map[string]string{
+			"invalid cursor %q": "неверный курсор %q",
+		}},
+	},
+	api.ErrorCodeInvalidRange: {
+		language.English: {title: "Invalid date range"},
+		language.Russian: {"Неверный диапазон дат", map[string]string{
+			"range starts on %s after it ends on %s": "диапазон начинается %s, позже своего конца %s",
+		}},
+	},
+	api.ErrorCodeEmptyQuery: {
+		language.English: {title: "Empty search query"},
+		language.Russian: {"Пустой поисковый запрос", map[string]string{
+			"query %q contains no words": "в запросе %q нет ни одного слова",
+		}},
+	},
+	api.ErrorCodeSelfFollow: {
+		language.English: {title: "Users can't follow themselves"},
+		language.Russian: {"Нельзя подписаться на себя", map[string]string{
+			"user %d can't follow themselves": "пользователь %d не может подписаться на себя",
+		}},
+	},
+
+	api.ErrorCodeUserNotFound: {
+		language.English: {title: "User not found"},
+		language.Russian: {"Пользователь не найден", map[string]string{
+			"user %d not found": "пользователь %d не найден",
+		}},
+	},
+	api.ErrorCodeBookNotFound: {
+		language.English: {title: "Book not found"},
+		language.Russian: {"Книга не найдена", map[string]string{
+			"book %d not found for user %d": "у пользователя %[2]d нет книги %[1]d",
+		}},
+	},
+	api.ErrorCodeNoteNotFound: {
+		language.English: {title: "Note not found"},
+		language.Russian: {"Заметка не найдена", map[string]string{
+			"%s %d not found for book %d": "у книги %[3]d нет заметки %[2]d",
+		}},
+	},
+	api.ErrorCodeHighlightNotFound: {
+		language.English: {title: "Highlight not found"},
+		language.Russian: {"Цитата не найдена", map[string]string{
+			"%s %d not found for book %d": "у книги %[3]d нет цитаты %[2]d",
+		}},
+	},
+	api.ErrorCodeBookmarkNotFound: {
+		language.English: {title: "Bookmark not found"},
+		language.Russian: {"Закладка не найдена", map[string]string{
+			"%s %d not found for book %d": "у книги %[3]d нет закладки %[2]d",
+		}},
+	},
+	api.ErrorCodeAuthorNotFound: {
+		language.English: {title: "Author not found"},
+		language.Russian: {"Автор не найден", map[string]string{
+			"author %d not found": "автор %d не найден",
+		}},
+	},
+	api.ErrorCodeClubNotFound: {
+		language.English: {title: "Club not found"},
+		language.Russian: {"Клуб не найден", map[string]string{
+			"club %d not found": "клуб %d не найден",
+		}},
+	},
+	api.ErrorCodeMemberNotFound: {
+		language.English: {title: "User is not a member of the club"},
+		language.Russian: {"Пользователь не состоит в клубе", map[string]string{
+			"user %d is not a member of the club": "пользователь %d не состоит в клубе",
+		}},
+	},
+	api.ErrorCodeShelfNotFound: {
+		language.English: {title: "Shelf not found"},
+		language.Russian: {"Полка не найдена", map[string]string{
+			"shelf %d not found for user %d": "у пользователя %[2]d нет полки %[1]d",
+		}},
+	},
+	api.ErrorCodeBookNotOnShelf: {
+		language.English: {title: "Book is not on the shelf"},
+		language.Russian: {"Книги нет на полке", map[string]string{
+			"book %d is not on shelf %q": "книги %d нет на полке %q",
+		}},
+	},
+	api.ErrorCodeCoverNotFound: {
+		language.English: {title: "Book has no cover"},
+		language.Russian: {"У книги нет обложки", map[string]string{
+			"book %d of user %d has no cover": "у книги %d пользователя %d нет обложки",
+		}},
+	},
+	api.ErrorCodeEditionNotFound: {
+		language.English: {title: "Edition not found"},
+		language.Russian: {"Издание не найдено", map[string]string{
+			"book %d has no edition %d": "у книги %d нет издания %d",
+		}},
+	},
+	api.ErrorCodePlanNotFound: {
+		language.English: {title: "Book has no reading plan"},
+		language.Russian: {"У книги нет плана чтения", map[string]string{
+			"book %d has no reading plan": "у книги %d нет плана чтения",
+		}},
+	},
+	api.ErrorCodeReviewNotFound: {
+		language.English: {title: "Review not found"},
+		language.Russian: {"Отзыв не найден", map[string]string{
+			"book %d is not reviewed by user %d": "пользователь %[2]d не оставлял отзыв на книгу %[1]d",
+		}},
+	},
+	api.ErrorCodeQueueEmpty: {
+		language.English: {title: "Reading queue is empty"},
+		language.Russian: {"Очередь чтения пуста", map[string]string{
+			"queue of user %d is empty": "очередь пользователя %d пуста",
+		}},
+	},
+	api.ErrorCodeNotFollowing: {
+		language.English: {title: "User is not followed"},
+		language.Russian: {"Подписки нет", map[string]string{
+			"user %d doesn't follow user %d": "пользователь %d не подписан на пользователя %d",
+		}},
+	},
+
+	api.ErrorCodeBookExists: {
+		language.English: {title: "User already has the book"},
+		language.Russian: {"Книга уже есть у пользователя", map[string]string{
+			"user %d already has the book with id %d, start a new read-through to reread it": "у пользователя %d уже есть книга с id %d, чтобы перечитать ее, начните новое прочтение",
+			"row %d: user %d is already reading the book with id %d":                         "строка %d: пользователь %d уже читает книгу с id %d",
+		}},
+	},
+	api.ErrorCodeAlreadyReading: {
+		language.English: {title: "Book is being read already"},
+		language.Russian: {"Книга уже читается", map[string]string{
+			"user %d is already reading the book with id %d": "пользователь %d уже читает книгу с id %d",
+		}},
+	},
+	api.ErrorCodeBookNotRead: {
+		language.English: {title: "Book is not read yet"},
+		language.Russian: {"Книга еще не прочитана", map[string]string{
+			"book %d is not read yet": "книга %d еще не прочитана",
+		}},
+	},
+	api.ErrorCodeBookRead: {
+		language.English: {title: "Book is read already"},
+		language.Russian: {"Книга уже прочитана", map[string]string{
+			"book %d is read already": "книга %d уже прочитана",
+		}},
+	},
+	api.ErrorCodeNotInQueue: {
+		language.English: {title: "Book is not in the reading queue"},
+		language.Russian: {"Книги нет в очереди чтения", map[string]string{
+			"book %d is not in want_to_read state": "книга %d не в статусе want_to_read",
+		}},
+	},
+	api.ErrorCodeShelfExists: {
+		language.English: {title: "Shelf already exists"},
+		language.Russian: {"Полка уже существует", map[string]string{
+			"shelf %q already exists": "полка %q уже существует",
+		}},
+	},
+	api.ErrorCodeLengthUnknown: {
+		language.English: {title: "Length of the book is unknown"},
+		language.Russian: {"Длина книги неизвестна", map[string]string{
+			"length of the book %d is unknown": "длина книги %d неизвестна",
+			"length of the current or the new edition is unknown, progress can't be carried over": "длина текущего или нового издания неизвестна, прогресс нельзя перенести",
+		}},
+	},
+	api.ErrorCodeAlreadyMember: {
+		language.English: {title: "User is a member of the club already"},
+		language.Russian: {"Пользователь уже состоит в клубе", map[string]string{
+			"user %d is a member of the club already": "пользователь %d уже состоит в клубе",
+		}},
+	},
+	api.ErrorCodeAlreadyInvited: {
+		language.English: {title: "User is invited to the club already"},
+		language.Russian: {"Пользователь уже приглашен в клуб", map[string]string{
+			"user %d is invited already": "пользователь %d уже приглашен",
+		}},
+	},
+	api.ErrorCodeNotInvited: {
+		language.English: {title: "User is not invited to the club"},
+		language.Russian: {"Пользователь не приглашен в клуб", map[string]string{
+			"user %d is not invited to the club": "пользователь %d не приглашен в клуб",
+		}},
+	},
+	api.ErrorCodeOwnerCannotLeave: {
+		language.English: {title: "Owner can't leave the club"},
+		language.Russian: {"Владелец не может покинуть клуб", map[string]string{
+			"owner of the club can't leave it": "владелец клуба не может из него выйти",
+		}},
+	},
+	api.ErrorCodeNoCurrentBook: {
+		language.English: {title: "Club has no current book"},
+		language.Russian: {"Клуб сейчас ничего не читает", map[string]string{
+			"club %d has no current book": "у клуба %d нет текущей книги",
+		}},
+	},
+
+	api.ErrorCodeImageTooLarge: {
+		language.English: {title: "Image is too large"},
+		language.Russian: {"Изображение слишком большое", map[string]string{
+			"image is larger than %d MiB": "изображение больше %d МиБ",
+		}},
+	},
+	api.ErrorCodeNotFound: {
+		language.English: {title: "No such route"},
+		language.Russian: {"Нет такого пути", map[string]string{
+			"no route for %s": "путь %s не найден",
+		}},
+	},
+	api.ErrorCodeMethodNotAllowed: {
+		language.English: {title: "Method not allowed"},
+		language.Russian: {"Метод не поддерживается", map[string]string{
+			"%s is not allowed for %s, allowed: %s": "метод %s не поддерживается для %s, допустимы: %s",
+		}},
+	},
+	api.ErrorCodeUnsupportedMediaType: {
+		language.English: {title: "Unsupported Content-Type"},
+		language.Russian: {"Неподдерживаемый Content-Type", map[string]string{
+			"Content-Type %q is not accepted by the operation": "операция не принимает Content-Type %q",
+		}},
+	},
+	api.ErrorCodeNotImplemented: {
+		language.English: {title: "Not implemented"},
+		language.Russian: {"Не реализовано", map[string]string{
+			"operation is not implemented yet": "операция еще не реализована",
+		}},
+	},
+	api.ErrorCodeInternal: {
+		language.English: {title: "Internal server error"},
+		language.Russian: {"Внутренняя ошибка сервера", map[string]string{
+			"request can't be completed, try again later": "запрос не удалось выполнить, попробуйте позже",
+		}},
+	},
+}
+
+// title возвращает заголовок ошибок с кодом code на языке lang, а если перевода нет - на английском
+func title(lang language.Tag, code api.ErrorCode) string {
+	if m, ok := catalog[code][lang]; ok {
+		return m.title
+	}
+	return catalog[code][language.English].title
+}
+
+// translate возвращает перевод формата на язык lang
+func translate(lang language.Tag, code api.ErrorCode, format string) string {
+	if translated, ok := catalog[code][lang].details[format]; ok {
+		return translated
+	}
+	return format
+}
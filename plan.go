@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// readingPlan - план дочитать книгу к сроку. Дни плана не хранятся, а расписываются заново от текущей
+// позиции, поэтому план сам подстраивается под настоящий прогресс
+type readingPlan struct {
+	deadline time.Time
+	restDays []api.Weekday
+	tz       string
+	loc      *time.Location
+	// позиция на начало дня: сегодняшняя часть считается от нее, а не от текущей позиции,
+	// иначе прочитанное за день уменьшало бы сегодняшнюю норму
+	startDay  string
+	startPage int
+}
+
+func weekdayOf(day time.Time) api.Weekday {
+	return api.Weekday(strings.ToLower(day.Weekday().String()))
+}
+
+// share возвращает i-ю из n почти равных частей amount, остаток достается первым частям
+func share(amount, n, i int) int {
+	if i < amount%n {
+		return amount/n + 1
+	}
+	return amount / n
+}
+
+// days расписывает план по дням от сегодняшнего до срока. Если сегодняшняя часть уже прочитана,
+// остаток делится между следующими днями
+func (p *readingPlan) days(book *api.Book, now time.Time) []api.PlanDay {
+	_, length, _ := bookUnit(book)
+	today := dayOf(now.In(p.loc))
+	var dates []time.Time
+	day, _ := time.Parse(time.DateOnly, today)
+	for ; dayOf(day) <= dayOf(p.deadline); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(p.restDays, weekdayOf(day)) {
+			dates = append(dates, day)
+		}
+	}
+
+	days := []api.PlanDay{}
+	position := book.Page
+	if len(dates) > 0 && dayOf(dates[0]) == today {
+		from := position
+		if p.startDay == today {
+			from = min(p.startPage, position)
+		}
+		to := from + share(max(length-from, 0), len(dates), 0)
+		days = append(days, api.PlanDay{Date: dates[0], From: from, To: to, Amount: to - from, Done: position >= to})
+		position = max(position, to)
+		dates = dates[1:]
+	}
+	rest := max(length-position, 0)
+	for i, date := range dates {
+		amount := share(rest, len(dates), i)
+		days = append(days, api.PlanDay{Date: date, From: position, To: position + amount, Amount: amount})
+		position += amount
+	}
+	return days
+}
+
+// view собирает план книги для ответа. Вызывается под s.mu
+func (p *readingPlan) view(book *api.Book) api.ReadingPlan {
+	unit, _, _ := bookUnit(book)
+	return api.ReadingPlan{
+		BookID:   book.ID,
+		Deadline: p.deadline,
+		RestDays: p.restDays,
+		TimeZone: p.tz,
+		Unit:     unit,
+		Days:     p.days(book, time.Now()),
+	}
+}
+
+// trackPlan запоминает позицию книги на начало дня для ее плана и удаляет план, когда книгу дочитали.
+// Вызывается под s.mu при каждом обновлении прогресса
+func (s *serviceImpl) trackPlan(userID int, before, after *api.Book) {
+	plan, ok := s.plans[userID][after.ID]
+	if !ok {
+		return
+	}
+	if after.Status == api.ReadingStatusRead {
+		delete(s.plans[userID], after.ID)
+		return
+	}
+	if today := dayOf(time.Now().In(plan.loc)); plan.startDay != today {
+		plan.startDay, plan.startPage = today, before.Page
+	}
+}
+
+func (s *serviceImpl) GetReadingPlan(ctx context.Context, params api.GetReadingPlanParams) (*api.ReadingPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	plan, ok := s.plans[params.UserID][params.BookID]
+	if !ok {
+		return nil, err(api.ErrorCodePlanNotFound, "book %d has no reading plan", params.BookID)
+	}
+	book := s.users[params.UserID][params.BookID]
+	view := plan.view(&book)
+	return &view, nil
+}
+
+func (s *serviceImpl) SetReadingPlan(ctx context.Context, req *api.NewReadingPlan, params api.SetReadingPlanParams) (*api.ReadingPlan, error) {
+	tz := req.TimeZone.Or("UTC")
+	loc, e := time.LoadLocation(tz)
+	if e != nil {
+		return nil, err(api.ErrorCodeUnknownTimeZone, "unknown time zone %q", tz)
+	}
+	// дни отдыха хранятся без повторов в порядке недели
+	restDays := slices.DeleteFunc(api.Weekday("").AllValues(), func(day api.Weekday) bool {
+		return !slices.Contains(req.RestDays, day)
+	})
+	if len(restDays) == 7 {
+		return nil, invalidField("rest_days", "every day of the week is a rest day")
+	}
+	today := dayOf(time.Now().In(loc))
+	if dayOf(req.Deadline) < today {
+		return nil, invalidField("deadline", "deadline %s is in the past", dayOf(req.Deadline))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	book := s.users[params.UserID][params.BookID]
+	if book.Status == api.ReadingStatusRead {
+		return nil, err(api.ErrorCodeBookRead, "book %d is read already", params.BookID)
+	}
+	if _, _, known := bookUnit(&book); !known {
+		return nil, err(api.ErrorCodeLengthUnknown, "length of the book %d is unknown", params.BookID)
+	}
+
+	plan := &readingPlan{deadline: req.Deadline, restDays: restDays, tz: tz, loc: loc, startDay: today, startPage: book.Page}
+	if _, ok := s.plans[params.UserID]; !ok {
+		s.plans[params.UserID] = make(map[int]*readingPlan)
+	}
+	s.plans[params.UserID][params.BookID] = plan
+	view := plan.view(&book)
+	return &view, nil
+}
+
+func (s *serviceImpl) RemoveReadingPlan(ctx context.Context, params api.RemoveReadingPlanParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return e
+	}
+	if _, ok := s.plans[params.UserID][params.BookID]; !ok {
+		return err(api.ErrorCodePlanNotFound, "book %d has no reading plan", params.BookID)
+	}
+	delete(s.plans[params.UserID], params.BookID)
+	return nil
+}
+
+// iCalendar
+
+// icsEscape экранирует текст значения по RFC 5545
+var icsEscape = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// writeICSLine пишет строку календаря, перенося ее по 75 байт так, чтобы не разрезать символы
+func writeICSLine(w *strings.Builder, line string) {
+	const limit = 75
+	width := 0
+	for _, r := range line {
+		if size := len(string(r)); width+size > limit {
+			w.WriteString("\r\n ")
+			width = 1
+		}
+		w.WriteRune(r)
+		width += len(string(r))
+	}
+	w.WriteString("\r\n")
+}
+
+func (s *serviceImpl) ExportReadingPlans(ctx context.Context, params api.ExportReadingPlansParams) (api.ExportReadingPlansOK, error) {
+	const icsDate = "20060102"
+	now := time.Now()
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	var ics strings.Builder
+	for _, line := range []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//mws//Reading plans//EN", "CALSCALE:GREGORIAN", "X-WR-CALNAME:Reading plan"} {
+		writeICSLine(&ics, line)
+	}
+
+	s.mu.RLock()
+	plans := s.plans[params.UserID]
+	for _, bookID := range slices.Sorted(maps.Keys(plans)) {
+		book := s.users[params.UserID][bookID]
+		unit, _, _ := bookUnit(&book)
+		for _, day := range plans[bookID].days(&book, now) {
+			if day.Amount == 0 {
+				continue
+			}
+			summary := fmt.Sprintf("Read %s: %s %d to %d", book.Title, unit, day.From, day.To)
+			for _, line := range []string{
+				"BEGIN:VEVENT",
+				fmt.Sprintf("UID:plan-%d-%d-%s@mws", params.UserID, bookID, day.Date.Format(icsDate)),
+				"DTSTAMP:" + stamp,
+				"DTSTART;VALUE=DATE:" + day.Date.Format(icsDate),
+				"DTEND;VALUE=DATE:" + day.Date.AddDate(0, 0, 1).Format(icsDate),
+				"SUMMARY:" + icsEscape.Replace(summary),
+				"TRANSP:TRANSPARENT",
+				"END:VEVENT",
+			} {
+				writeICSLine(&ics, line)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	writeICSLine(&ics, "END:VCALENDAR")
+	return api.ExportReadingPlansOK{Data: strings.NewReader(ics.String())}, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"math"
+
+	api "mws/gen_api"
+)
+
+// Прогресс книги хранится в page в единицах читаемого издания: страницах, позициях читалки,
+// процентах или секундах аудиокниги. Без издания прогресс считается в страницах
+
+// editionUnit возвращает единицу прогресса издания, по умолчанию аудиокниги меряются в секундах, остальные - в страницах
+func editionUnit(edition *api.Edition) api.ProgressUnit {
+	if unit, ok := edition.Unit.Get(); ok {
+		return unit
+	} else if edition.Format == api.EditionFormatAudiobook {
+		return api.ProgressUnitSeconds
+	}
+	return api.ProgressUnitPage
+}
+
+// editionLength возвращает длину издания в его единицах, если она известна
+func editionLength(edition *api.Edition) (int, bool) {
+	if editionUnit(edition) == api.ProgressUnitPercent {
+		return 100, true
+	}
+	return edition.Length.Get()
+}
+
+// bookUnit возвращает единицу прогресса книги и ее длину в этих единицах, если она известна
+func bookUnit(book *api.Book) (api.ProgressUnit, int, bool) {
+	if id, ok := book.Edition.Get(); ok {
+		if edition := findEdition(book, id); edition != nil {
+			length, ok := editionLength(edition)
+			return editionUnit(edition), length, ok
+		}
+	}
+	pages, ok := book.Pages.Get()
+	return api.ProgressUnitPage, pages, ok
+}
+
+// firstPosition - начальная позиция: страницы и позиции читалки нумеруются с единицы, проценты и секунды - с нуля
+func firstPosition(unit api.ProgressUnit) int {
+	if unit == api.ProgressUnitPage || unit == api.ProgressUnitLocation {
+		return 1
+	}
+	return 0
+}
+
+// fraction переводит позицию в долю прочитанного от 0 до 1 для статистики
+func fraction(position, length int, unit api.ProgressUnit) float64 {
+	first := firstPosition(unit)
+	if length <= first {
+		return 1
+	}
+	return math.Max(0, math.Min(1, float64(position-first)/float64(length-first)))
+}
+
+// fromFraction переводит долю прочитанного в позицию издания длиной length
+func fromFraction(f float64, length int, unit api.ProgressUnit) int {
+	first := firstPosition(unit)
+	return first + int(math.Round(f*float64(length-first)))
+}
+
+// progress возвращает долю прочитанного в книге, если известна ее длина
+func progress(book *api.Book) (float64, bool) {
+	unit, length, ok := bookUnit(book)
+	if !ok {
+		return 0, false
+	}
+	return fraction(book.Page, length, unit), true
+}
+
+// positionFrom проверяет присланную позицию и переводит ее в единицы книги.
+// Проценты переводятся в любые единицы, если известна длина книги
+func positionFrom(book *api.Book, position int, unit api.ProgressUnit) (int, error) {
+	bookUnit, length, known := bookUnit(book)
+	if unit != bookUnit && unit != api.ProgressUnitPercent {
+		return 0, invalidField("unit", "progress of the book is measured in %s, not in %s", bookUnit, unit)
+	}
+	if unit == api.ProgressUnitPercent && bookUnit != api.ProgressUnitPercent {
+		if position < 0 || position > 100 {
+			return 0, invalidField("page", "percent must be from 0 to 100, got %d", position)
+		}
+		if !known {
+			return 0, invalidField("unit", "length of the book is unknown, percent can't be converted to %s", bookUnit)
+		}
+		return fromFraction(float64(position)/100, length, bookUnit), nil
+	}
+
+	if first := firstPosition(unit); position < first {
+		return 0, invalidField("page", "%s must be at least %d, got %d", unit, first, position)
+	}
+	if known && position > length {
+		return 0, invalidField("page", "%s %d is past the end of the book, its length is %d", unit, position, length)
+	}
+	return position, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	api "mws/gen_api"
+)
+
+// Очередь - книги в статусе want_to_read в порядке, заданном пользователем. Порядок задается
+// дробными ключами: строками, которые сравниваются лексикографически. Чтобы поставить книгу между
+// двумя соседями, ей достаточно выдать ключ между их ключами, остальные книги не меняются
+
+// цифры ключей в порядке возрастания кодов символов, чтобы строки сравнивались как числа
+const keyDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// keyBetween возвращает ключ строго между a и b. Пустой a - начало очереди, пустой b - конец.
+// Ключи никогда не заканчиваются нулевой цифрой, поэтому между любыми двумя ключами есть место
+func keyBetween(a, b string) string {
+	if b != "" {
+		// общий префикс переносится как есть
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + keyBetween(suffix(a, n), b[n:])
+		}
+	}
+
+	lo := strings.IndexByte(keyDigits, digitAt(a, 0))
+	hi := len(keyDigits)
+	if b != "" {
+		hi = strings.IndexByte(keyDigits, b[0])
+	}
+	if hi-lo > 1 {
+		return string(keyDigits[(lo+hi+1)/2])
+	}
+	// первые цифры соседние: если b длиннее, то подходит его первая цифра, иначе уходим на разряд ниже
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(keyDigits[lo]) + keyBetween(suffix(a, 1), "")
+}
+
+// digitAt возвращает i-ю цифру ключа, недостающие цифры считаются нулями
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return keyDigits[0]
+}
+
+func suffix(key string, i int) string {
+	if i < len(key) {
+		return key[i:]
+	}
+	return ""
+}
+
+type queueEntry struct {
+	key    string
+	bookID int
+}
+
+// queueOf возвращает очередь пользователя по порядку. Вызывается под s.mu
+func (s *serviceImpl) queueOf(userID int) []queueEntry {
+	entries := make([]queueEntry, 0, len(s.queue[userID]))
+	for id, key := range s.queue[userID] {
+		entries = append(entries, queueEntry{key: key, bookID: id})
+	}
+	slices.SortFunc(entries, func(a, b queueEntry) int {
+		return cmp.Or(strings.Compare(a.key, b.key), cmp.Compare(a.bookID, b.bookID))
+	})
+	return entries
+}
+
+// syncQueue ставит книгу в конец очереди, когда ее хотят прочитать, и убирает из очереди при
+// смене статуса. Вызывается под s.mu после каждого изменения книги
+func (s *serviceImpl) syncQueue(userID int, book *api.Book) {
+	_, queued := s.queue[userID][book.ID]
+	if book.Status != api.ReadingStatusWantToRead {
+		delete(s.queue[userID], book.ID)
+		return
+	} else if queued {
+		return
+	}
+
+	last := ""
+	for _, key := range s.queue[userID] {
+		last = max(last, key)
+	}
+	if _, ok := s.queue[userID]; !ok {
+		s.queue[userID] = make(map[int]string)
+	}
+	s.queue[userID][book.ID] = keyBetween(last, "")
+}
+
+func (s *serviceImpl) GetReadingQueue(ctx context.Context, params api.GetReadingQueueParams) ([]api.QueueItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := s.queueOf(params.UserID)
+	items := make([]api.QueueItem, 0, len(entries))
+	for i, entry := range entries {
+		items = append(items, api.QueueItem{Position: i + 1, Book: s.present(params.UserID, s.users[params.UserID][entry.bookID])})
+	}
+	return items, nil
+}
+
+func (s *serviceImpl) MoveInReadingQueue(ctx context.Context, req *api.QueueMove, params api.MoveInReadingQueueParams) (*api.QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	if _, ok := s.queue[params.UserID][params.BookID]; !ok {
+		return nil, err(api.ErrorCodeNotInQueue, "book %d is not in want_to_read state", params.BookID)
+	}
+
+	others := slices.DeleteFunc(s.queueOf(params.UserID), func(e queueEntry) bool { return e.bookID == params.BookID })
+	position := min(req.Position, len(others)+1)
+	before, after := "", ""
+	if position > 1 {
+		before = others[position-2].key
+	}
+	if position <= len(others) {
+		after = others[position-1].key
+	}
+	s.queue[params.UserID][params.BookID] = keyBetween(before, after)
+
+	return &api.QueueItem{Position: position, Book: s.present(params.UserID, s.users[params.UserID][params.BookID])}, nil
+}
+
+func (s *serviceImpl) PopReadingQueue(ctx context.Context, params api.PopReadingQueueParams) (*api.Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.queueOf(params.UserID)
+	if len(entries) == 0 {
+		return nil, err(api.ErrorCodeQueueEmpty, "queue of user %d is empty", params.UserID)
+	}
+	book := s.users[params.UserID][entries[0].bookID]
+	setStatus(&book, api.ReadingStatusReading)
+	s.users[params.UserID][book.ID] = book
+	s.syncQueue(params.UserID, &book)
+	s.publishStatus(params.UserID, api.ReadingStatusWantToRead, &book)
+
+	book = s.present(params.UserID, book)
+	return &book, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// checkKeyBetween проверяет, что ключ лежит строго между соседями и годится как сосед для следующих вставок
+func checkKeyBetween(t *testing.T, a, b string) string {
+	t.Helper()
+	key := keyBetween(a, b)
+	if key == "" || strings.Trim(key, keyDigits) != "" {
+		t.Fatalf("keyBetween(%q, %q) = %q, want non-empty key of key digits", a, b, key)
+	}
+	if key[len(key)-1] == keyDigits[0] {
+		t.Fatalf("keyBetween(%q, %q) = %q ends with zero digit", a, b, key)
+	}
+	if key <= a || b != "" && key >= b {
+		t.Fatalf("keyBetween(%q, %q) = %q is not between them", a, b, key)
+	}
+	return key
+}
+
+func TestKeyBetweenRandomInserts(t *testing.T) {
+	seed := rand.Uint64()
+	t.Logf("seed %d", seed)
+	rnd := rand.New(rand.NewPCG(seed, seed))
+
+	for _, tt := range []struct {
+		name     string
+		position func(n int) int
+	}{
+		{"random", func(n int) int { return rnd.IntN(n + 1) }},
+		{"front", func(n int) int { return 0 }},
+		{"end", func(n int) int { return n }},
+		// все время между первыми двумя, ключи растут в длину быстрее всего
+		{"after first", func(n int) int { return min(n, 1) }},
+		{"before last", func(n int) int { return max(n-1, 0) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			for range 2000 {
+				i := tt.position(len(keys))
+				var a, b string
+				if i > 0 {
+					a = keys[i-1]
+				}
+				if i < len(keys) {
+					b = keys[i]
+				}
+				keys = slices.Insert(keys, i, checkKeyBetween(t, a, b))
+			}
+			if !slices.IsSorted(keys) {
+				t.Error("keys are out of order")
+			}
+		})
+	}
+}
+
+func TestKeyBetweenRandomPairs(t *testing.T) {
+	seed := rand.Uint64()
+	t.Logf("seed %d", seed)
+	rnd := rand.New(rand.NewPCG(seed, seed))
+
+	// случайный ключ без нуля в конце, короткие ключи и крайние цифры чаще дают общий префикс и соседние цифры
+	randomKey := func() string {
+		digits := []byte{keyDigits[0], keyDigits[1], keyDigits[len(keyDigits)-1], keyDigits[rnd.IntN(len(keyDigits))]}
+		key := make([]byte, 1+rnd.IntN(4))
+		for i := range key {
+			key[i] = digits[rnd.IntN(len(digits))]
+		}
+		if key[len(key)-1] == keyDigits[0] {
+			key[len(key)-1] = keyDigits[1]
+		}
+		return string(key)
+	}
+	for range 100000 {
+		a, b := randomKey(), randomKey()
+		if a == b {
+			continue
+		}
+		if a > b {
+			a, b = b, a
+		}
+		checkKeyBetween(t, a, b)
+		checkKeyBetween(t, "", a)
+		checkKeyBetween(t, b, "")
+	}
+}
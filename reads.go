@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Каждое прочтение книги хранится отдельно в book.Reads, page, status и finished самой книги
+// описывают последнее прочтение. Незавершенным может быть только последнее прочтение - текущее
+
+func today() api.OptDate {
+	return api.NewOptDate(time.Now())
+}
+
+// activeRead возвращает текущее прочтение или nil, если книгу сейчас не читают
+func activeRead(book *api.Book) *api.ReadThrough {
+	if n := len(book.Reads); n > 0 && !book.Reads[n-1].Completed {
+		return &book.Reads[n-1]
+	}
+	return nil
+}
+
+// lastFinished возвращает дату окончания последнего завершенного прочтения
+func lastFinished(book *api.Book) api.OptDate {
+	for i := len(book.Reads) - 1; i >= 0; i-- {
+		if book.Reads[i].Completed {
+			return book.Reads[i].Finished
+		}
+	}
+	return api.OptDate{}
+}
+
+// initialReads заполняет прочтения новой книги по ее статусу, если их не прислали
+func initialReads(book *api.Book) {
+	switch book.Status {
+	case api.ReadingStatusReading:
+		book.Reads = []api.ReadThrough{{Started: today(), Page: book.Page}}
+	case api.ReadingStatusRead:
+		book.Reads = []api.ReadThrough{{Finished: book.Finished, Page: book.Page, Completed: true}}
+	default:
+		book.Reads = nil
+	}
+}
+
+// applyReads проверяет присланные прочтения и выводит из последнего страницу, статус и дату окончания книги.
+// Если все прочтения завершены, книгу можно хотеть перечитать
+func applyReads(book *api.Book, reads []api.ReadThrough) error {
+	for i, r := range reads {
+		if !r.Completed && i != len(reads)-1 {
+			return invalidField("reads", "only the last read-through may be not completed")
+		}
+		if r.Finished.Set && !r.Completed {
+			return invalidField("reads", "read-through %d has finish date but is not completed", i+1)
+		}
+		if r.Started.Set && r.Finished.Set && r.Finished.Value.Before(r.Started.Value) {
+			return invalidField("reads", "read-through %d is finished before it is started", i+1)
+		}
+		if r.Page < 1 {
+			return invalidField("reads", "page of read-through %d must be positive, got %d", i+1, r.Page)
+		}
+	}
+
+	book.Reads = reads
+	if len(reads) == 0 {
+		return nil
+	}
+	last := reads[len(reads)-1]
+	book.Page = last.Page
+	if !last.Completed {
+		book.Status = api.ReadingStatusReading
+	} else if book.Status == api.ReadingStatusReading {
+		book.Status = api.ReadingStatusRead
+	}
+	book.Finished.Reset()
+	if book.Status == api.ReadingStatusRead {
+		book.Finished = last.Finished
+	}
+	return nil
+}
+
+// setStatus меняет статус книги и ее прочтений. Начало чтения прочитанной книги начинает новое прочтение,
+// окончание завершает текущее и проставляет дату окончания
+func setStatus(book *api.Book, status api.ReadingStatus) {
+	// копия книги делит прочтения с книгой на полке, которую могут в это время отдавать клиенту
+	book.Reads = slices.Clone(book.Reads)
+	active := activeRead(book)
+	switch status {
+	case api.ReadingStatusRead:
+		if active == nil && book.Status != api.ReadingStatusRead {
+			// книгу прочитали сразу из списка желаемого
+			book.Reads = append(book.Reads, api.ReadThrough{Page: book.Page})
+			active = activeRead(book)
+		}
+		if active != nil {
+			active.Completed, active.Finished = true, today()
+		}
+		book.Finished = lastFinished(book)
+	case api.ReadingStatusReading:
+		if active == nil {
+			book.Reads = append(book.Reads, api.ReadThrough{Started: today(), Page: book.Page})
+		}
+		book.Finished.Reset()
+	default:
+		// начатое прочтение отменяется, прошлые остаются
+		if active != nil {
+			book.Reads = book.Reads[:len(book.Reads)-1]
+		}
+		book.Finished.Reset()
+	}
+	book.Status = status
+}
+
+// setPage меняет страницу книги и ее текущего прочтения, завершенные прочтения не меняются
+func setPage(book *api.Book, page int) {
+	book.Page = page
+	if activeRead(book) != nil {
+		book.Reads = slices.Clone(book.Reads)
+		activeRead(book).Page = page
+	}
+}
+
+func (s *serviceImpl) StartReread(ctx context.Context, params api.StartRereadParams) (*api.Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	book := s.users[params.UserID][params.BookID]
+	before := book.Status
+	if activeRead(&book) != nil {
+		return nil, err(api.ErrorCodeAlreadyReading, "user %d is already reading the book with id %d", params.UserID, params.BookID)
+	}
+
+	setStatus(&book, api.ReadingStatusReading)
+	setPage(&book, 1)
+	s.users[params.UserID][params.BookID] = book
+	s.syncQueue(params.UserID, &book)
+	s.publishStatus(params.UserID, before, &book)
+
+	book = s.present(params.UserID, book)
+	return &book, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+
+	api "mws/gen_api"
+)
+
+// Рекомендации строятся по совместному чтению: две книги похожи, если их часто держат на полках
+// одни и те же пользователи. Счетчики обновляются при каждом изменении полок, так что для ответа
+// не нужно обходить полки всех пользователей
+
+// сколько своих книг пользователя объясняют рекомендацию
+const becauseLimit = 3
+
+// coReading - сколько пользователей держат на полке каждую книгу и каждую пару книг
+type coReading struct {
+	readers map[int]int
+	// bookID -> bookID -> число общих читателей, хранится в обе стороны
+	pairs map[int]map[int]int
+}
+
+func newCoReading() *coReading {
+	return &coReading{readers: make(map[int]int), pairs: make(map[int]map[int]int)}
+}
+
+// update учитывает, что у пользователя появилась (delta = 1) или пропала (delta = -1) книга,
+// others - остальные книги на его полке. Вызывается под s.mu
+func (c *coReading) update(bookID int, others map[int]api.Book, delta int) {
+	c.readers[bookID] += delta
+	if c.readers[bookID] == 0 {
+		delete(c.readers, bookID)
+	}
+	for other := range others {
+		if other != bookID {
+			c.addPair(bookID, other, delta)
+			c.addPair(other, bookID, delta)
+		}
+	}
+}
+
+func (c *coReading) addPair(a, b, delta int) {
+	if _, ok := c.pairs[a]; !ok {
+		c.pairs[a] = make(map[int]int)
+	}
+	if c.pairs[a][b] += delta; c.pairs[a][b] == 0 {
+		delete(c.pairs[a], b)
+		if len(c.pairs[a]) == 0 {
+			delete(c.pairs, a)
+		}
+	}
+}
+
+// similarity считает похожесть книг a и b, у которых common общих читателей
+func (c *coReading) similarity(a, b, common int, metric api.SimilarityMetric) float64 {
+	ra, rb := float64(c.readers[a]), float64(c.readers[b])
+	if metric == api.SimilarityMetricJaccard {
+		return float64(common) / (ra + rb - float64(common))
+	}
+	return float64(common) / math.Sqrt(ra*rb)
+}
+
+// anyCopy находит книгу на полке любого пользователя, чтобы показать ее название. Вызывается под s.mu
+func (s *serviceImpl) anyCopy(bookID int) (api.Book, bool) {
+	for _, books := range s.users {
+		if book, ok := books[bookID]; ok {
+			return book, true
+		}
+	}
+	return api.Book{}, false
+}
+
+func (s *serviceImpl) GetRecommendations(ctx context.Context, params api.GetRecommendationsParams) ([]api.Recommendation, error) {
+	metric := params.Metric.Or(api.SimilarityMetricCosine)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type because struct {
+		bookID int
+		score  float64
+	}
+	type candidate struct {
+		score   float64
+		because []because
+	}
+	shelf := s.users[params.UserID]
+	candidates := make(map[int]*candidate)
+	for own := range shelf {
+		for other, common := range s.coReading.pairs[own] {
+			if _, ok := shelf[other]; ok {
+				continue
+			}
+			if _, ok := candidates[other]; !ok {
+				candidates[other] = &candidate{}
+			}
+			similarity := s.coReading.similarity(own, other, common, metric)
+			candidates[other].score += similarity
+			candidates[other].because = append(candidates[other].because, because{own, similarity})
+		}
+	}
+
+	ids := make([]int, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b int) int {
+		return cmp.Or(cmp.Compare(candidates[b].score, candidates[a].score), cmp.Compare(a, b))
+	})
+	ids = ids[:min(len(ids), params.Limit.Or(10))]
+
+	recommendations := make([]api.Recommendation, 0, len(ids))
+	for _, id := range ids {
+		book, _ := s.anyCopy(id)
+		c := candidates[id]
+		slices.SortFunc(c.because, func(a, b because) int {
+			return cmp.Or(cmp.Compare(b.score, a.score), cmp.Compare(a.bookID, b.bookID))
+		})
+		r := api.Recommendation{BookID: id, Title: book.Title, Author: book.Author, Score: c.score, Because: []int{}}
+		for _, b := range c.because[:min(len(c.because), becauseLimit)] {
+			r.Because = append(r.Because, b.bookID)
+		}
+		recommendations = append(recommendations, r)
+	}
+	return recommendations, nil
+}
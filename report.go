@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"html/template"
+	"slices"
+	"strconv"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Итоги года - html страница, которую можно открыть без сервера: графики рисуются на сервере
+// в svg прямо внутри страницы, без скриптов
+
+// размеры столбчатой диаграммы в пикселях
+const (
+	barWidth    = 32
+	barGap      = 8
+	chartTop    = 16
+	chartBars   = 160
+	chartBottom = 20
+)
+
+type bar struct {
+	X, Y, Width, Height int
+	// середина столбца, по ней выравниваются подписи
+	Center int
+	Label  string
+	Value  int
+}
+
+type barChart struct {
+	Title         string
+	Width, Height int
+	LabelY        int
+	Bars          []bar
+}
+
+// monthChart рисует столбцы по месяцам, самый высокий столбец занимает всю высоту диаграммы
+func monthChart(title string, values [12]int) barChart {
+	chart := barChart{
+		Title:  title,
+		Width:  len(values) * (barWidth + barGap),
+		Height: chartTop + chartBars + chartBottom,
+		LabelY: chartTop + chartBars + chartBottom - 6,
+	}
+	highest := max(slices.Max(values[:]), 1)
+	for i, value := range values {
+		height := value * chartBars / highest
+		chart.Bars = append(chart.Bars, bar{
+			X:      i*(barWidth+barGap) + barGap/2,
+			Y:      chartTop + chartBars - height,
+			Width:  barWidth,
+			Height: height,
+			Center: i*(barWidth+barGap) + barGap/2 + barWidth/2,
+			Label:  time.Month(i + 1).String()[:3],
+			Value:  value,
+		})
+	}
+	return chart
+}
+
+type reportBook struct {
+	Title, Author string
+	Pages, Days   int
+	Date          string
+}
+
+type yearReport struct {
+	Year         int
+	Books, Pages int
+	Longest      *reportBook
+	Fastest      *reportBook
+	Charts       []barChart
+	Finished     []reportBook
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Year}} in books</title>
+<style>
+body { font-family: sans-serif; max-width: 42rem; margin: 2rem auto; color: #222; }
+.totals { display: flex; gap: 3rem; font-size: 1.2rem; }
+.totals b { display: block; font-size: 2.5rem; }
+svg rect { fill: #4a7bb7; }
+svg text { font-size: 11px; text-anchor: middle; fill: #555; }
+</style>
+</head>
+<body>
+<h1>{{.Year}} in books</h1>
+<div class="totals">
+<div><b>{{.Books}}</b> books finished</div>
+<div><b>{{.Pages}}</b> pages read</div>
+</div>
+{{with .Longest}}<p>Longest book: <b>{{.Title}}</b> by {{.Author}}, {{.Pages}} pages</p>{{end}}
+{{with .Fastest}}<p>Fastest read: <b>{{.Title}}</b> by {{.Author}} in {{.Days}} {{if eq .Days 1}}day{{else}}days{{end}}</p>{{end}}
+{{range .Charts}}{{$chart := .}}
+<h2>{{.Title}}</h2>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}" role="img">
+{{range .Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}"><title>{{.Label}}: {{.Value}}</title></rect>
+{{if .Value}}<text x="{{.Center}}" y="{{.Y}}" dy="-4">{{.Value}}</text>
+{{end}}<text x="{{.Center}}" y="{{$chart.LabelY}}">{{.Label}}</text>
+{{end}}</svg>
+{{end}}
+{{if .Finished}}<h2>Finished books</h2>
+<ol>
+{{range .Finished}}<li><b>{{.Title}}</b> by {{.Author}}, {{.Date}}</li>
+{{end}}</ol>{{end}}
+</body>
+</html>
+`))
+
+func (s *serviceImpl) GetYearReport(ctx context.Context, params api.GetYearReportParams) (api.GetYearReportOK, error) {
+	tz := params.Tz.Or("UTC")
+	loc, e := time.LoadLocation(tz)
+	if e != nil {
+		return api.GetYearReportOK{}, err(api.ErrorCodeUnknownTimeZone, "unknown time zone %q", tz)
+	}
+	report := yearReport{Year: params.Year}
+	year := strconv.Itoa(params.Year)
+
+	s.mu.RLock()
+	if _, ok := s.users[params.UserID]; !ok {
+		s.mu.RUnlock()
+		return api.GetYearReportOK{}, err(api.ErrorCodeUserNotFound, "user %d not found", params.UserID)
+	}
+	var books, pages [12]int
+	for _, f := range s.finishedReads(params.UserID) {
+		if f.day[:4] != year {
+			continue
+		}
+		month, _ := strconv.Atoi(f.day[5:7])
+		books[month-1]++
+		book := reportBook{Title: f.book.Title, Author: f.book.Author, Pages: f.book.Pages.Or(0), Date: f.day}
+		if f.read.Started.Set {
+			book.Days = daysBetween(f.read.Started.Value, f.read.Finished.Value)
+		}
+		report.Finished = append(report.Finished, book)
+	}
+	for _, event := range s.pageLog[params.UserID] {
+		if at := event.at.In(loc); at.Year() == params.Year {
+			pages[at.Month()-1] += event.pages
+			report.Pages += event.pages
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(report.Finished, func(a, b reportBook) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.Title, b.Title))
+	})
+	report.Books = len(report.Finished)
+	for i := range report.Finished {
+		book := &report.Finished[i]
+		if book.Pages > 0 && (report.Longest == nil || book.Pages > report.Longest.Pages) {
+			report.Longest = book
+		}
+		if book.Days > 0 && (report.Fastest == nil || book.Days < report.Fastest.Days) {
+			report.Fastest = book
+		}
+	}
+	report.Charts = []barChart{monthChart("Books finished", books), monthChart("Pages read", pages)}
+
+	var page bytes.Buffer
+	if e := reportTemplate.Execute(&page, report); e != nil {
+		return api.GetYearReportOK{}, e
+	}
+	return api.GetYearReportOK{Data: &page}, nil
+}
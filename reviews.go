@@ -0,0 +1,192 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// оценки хранятся в половинах звезды: 2..10 соответствуют 1..5 звездам
+const (
+	minRating = 2
+	maxRating = 10
+)
+
+func halfStars(r api.Rating) (int, bool) {
+	half := float64(r) * 2
+	if half != math.Trunc(half) || half < minRating || half > maxRating {
+		return 0, false
+	}
+	return int(half), true
+}
+
+// ratingStats - оценки одной книги всеми пользователями. Обновляется при каждом изменении оценки,
+// так что для ответа не нужно обходить все полки
+type ratingStats struct {
+	count     int
+	sum       int
+	histogram [maxRating - minRating + 1]int
+}
+
+func (r *ratingStats) add(half, delta int) {
+	r.count += delta
+	r.sum += half * delta
+	r.histogram[half-minRating] += delta
+}
+
+func (r *ratingStats) summary() api.RatingSummary {
+	summary := api.RatingSummary{Count: r.count, Histogram: make([]api.RatingCount, 0, len(r.histogram))}
+	if r.count > 0 {
+		summary.Average = float64(r.sum) / 2 / float64(r.count)
+	}
+	for i, n := range r.histogram {
+		summary.Histogram = append(summary.Histogram, api.RatingCount{Rating: api.Rating(float64(i+minRating) / 2), Count: n})
+	}
+	return summary
+}
+
+// ratingSummary возвращает оценки книги, в том числе пустые, если ее никто не оценил. Вызывается под s.mu
+func (s *serviceImpl) ratingSummary(bookID int) api.RatingSummary {
+	if stats, ok := s.ratings[bookID]; ok {
+		return stats.summary()
+	}
+	return (&ratingStats{}).summary()
+}
+
+// setReview сохраняет отзыв пользователя и пересчитывает оценки книги. Вызывается под s.mu
+func (s *serviceImpl) setReview(review api.Review) {
+	s.dropReview(review.UserID, review.BookID)
+	if _, ok := s.reviews[review.BookID]; !ok {
+		s.reviews[review.BookID] = make(map[int]api.Review)
+	}
+	s.reviews[review.BookID][review.UserID] = review
+
+	stats, ok := s.ratings[review.BookID]
+	if !ok {
+		stats = &ratingStats{}
+		s.ratings[review.BookID] = stats
+	}
+	half, _ := halfStars(review.Rating)
+	stats.add(half, 1)
+}
+
+// dropReview удаляет отзыв пользователя, если он есть, и пересчитывает оценки книги. Вызывается под s.mu
+func (s *serviceImpl) dropReview(userID, bookID int) bool {
+	review, ok := s.reviews[bookID][userID]
+	if !ok {
+		return false
+	}
+	delete(s.reviews[bookID], userID)
+
+	stats := s.ratings[bookID]
+	half, _ := halfStars(review.Rating)
+	stats.add(half, -1)
+	if stats.count == 0 {
+		delete(s.reviews, bookID)
+		delete(s.ratings, bookID)
+	}
+	return true
+}
+
+func (s *serviceImpl) GetBookReview(ctx context.Context, params api.GetBookReviewParams) (*api.Review, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	} else if review, ok := s.reviews[params.BookID][params.UserID]; !ok {
+		return nil, err(api.ErrorCodeReviewNotFound, "book %d is not reviewed by user %d", params.BookID, params.UserID)
+	} else {
+		return &review, nil
+	}
+}
+
+func (s *serviceImpl) PutBookReview(ctx context.Context, req *api.NewReview, params api.PutBookReviewParams) (*api.Review, error) {
+	var c checks
+	c.optText("text", &req.Text)
+	if _, ok := halfStars(req.Rating); !ok {
+		c.add("rating", "rating must be from 1 to 5 with half-star steps, got %v", req.Rating)
+	}
+	if e := c.problem(); e != nil {
+		return nil, e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return nil, e
+	}
+	// оценивать можно только прочитанные книги
+	if book := s.users[params.UserID][params.BookID]; book.Status != api.ReadingStatusRead {
+		return nil, err(api.ErrorCodeBookNotRead, "book %d is not read yet", params.BookID)
+	}
+
+	review := api.Review{
+		UserID:     params.UserID,
+		BookID:     params.BookID,
+		Rating:     req.Rating,
+		Text:       req.Text,
+		Visibility: req.Visibility.Or(api.ReviewVisibilityPrivate),
+		Updated:    time.Now(),
+	}
+	s.setReview(review)
+	if review.Visibility != api.ReviewVisibilityPrivate {
+		book := s.users[params.UserID][params.BookID]
+		s.publish(api.Activity{UserID: params.UserID, Type: api.ActivityTypeRated, BookID: book.ID, Title: book.Title,
+			Author: api.NewOptString(book.Author), Rating: api.NewOptRating(review.Rating)})
+	}
+	return &review, nil
+}
+
+func (s *serviceImpl) RemoveBookReview(ctx context.Context, params api.RemoveBookReviewParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return e
+	} else if !s.dropReview(params.UserID, params.BookID) {
+		return err(api.ErrorCodeReviewNotFound, "book %d is not reviewed by user %d", params.BookID, params.UserID)
+	}
+	return nil
+}
+
+// reviewVisible проверяет, может ли viewer читать отзыв. viewer = 0 - анонимный читатель. Вызывается под s.mu
+func (s *serviceImpl) reviewVisible(review *api.Review, viewer int) bool {
+	switch review.Visibility {
+	case api.ReviewVisibilityPublic:
+		return true
+	case api.ReviewVisibilityShared:
+		return viewer == review.UserID || s.follows(viewer, review.UserID)
+	default:
+		return viewer == review.UserID
+	}
+}
+
+func (s *serviceImpl) GetBookReviews(ctx context.Context, params api.GetBookReviewsParams) (*api.BookReviews, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	viewer := params.ViewerID.Or(0)
+	reviews := []api.Review{}
+	for _, review := range s.reviews[params.BookID] {
+		// оценки без текста учитываются только в рейтинге
+		if review.Text.Set && s.reviewVisible(&review, viewer) {
+			reviews = append(reviews, review)
+		}
+	}
+	slices.SortFunc(reviews, func(a, b api.Review) int {
+		if c := b.Updated.Compare(a.Updated); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	if limit := params.Limit.Or(20); len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return &api.BookReviews{Ratings: s.ratingSummary(params.BookID), Reviews: reviews}, nil
+}
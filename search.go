@@ -0,0 +1,187 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	api "mws/gen_api"
+)
+
+// веса полей книги: совпадение в названии важнее совпадения в авторе
+const (
+	titleWeight  = 2
+	authorWeight = 1
+)
+
+// searchIndex - инвертированный индекс по полкам пользователей:
+// userID -> терм -> bookID -> вес терма в книге.
+// Обновляется под тем же мьютексом что и serviceImpl.users
+type searchIndex struct {
+	users map[int]map[string]map[int]int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		users: make(map[int]map[string]map[int]int),
+	}
+}
+
+// bookTerms возвращает термы книги вместе с их весами
+func bookTerms(book *api.Book) map[string]int {
+	terms := make(map[string]int)
+	for _, t := range tokenize(book.Title) {
+		terms[t] += titleWeight
+	}
+	for _, t := range tokenize(book.Author) {
+		terms[t] += authorWeight
+	}
+	return terms
+}
+
+func (idx *searchIndex) add(userID int, book *api.Book) {
+	terms, ok := idx.users[userID]
+	if !ok {
+		terms = make(map[string]map[int]int)
+		idx.users[userID] = terms
+	}
+	for term, weight := range bookTerms(book) {
+		if _, ok := terms[term]; !ok {
+			terms[term] = make(map[int]int)
+		}
+		terms[term][book.ID] = weight
+	}
+}
+
+func (idx *searchIndex) remove(userID int, book *api.Book) {
+	terms, ok := idx.users[userID]
+	if !ok {
+		return
+	}
+	for term := range bookTerms(book) {
+		delete(terms[term], book.ID)
+		if len(terms[term]) == 0 {
+			delete(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		delete(idx.users, userID)
+	}
+}
+
+// search возвращает id книг с их релевантностью. Каждое слово запроса должно
+// найтись в книге, последнее слово запроса (которое пользователь может еще не дописать)
+// сопоставляется как префикс
+func (idx *searchIndex) search(userID int, query []string) map[int]float64 {
+	terms := idx.users[userID]
+	var scores map[int]float64
+	for i, q := range query {
+		matched := make(map[int]float64)
+		for book, weight := range terms[q] {
+			matched[book] = 2 * float64(weight)
+		}
+		if i == len(query)-1 {
+			for term, books := range terms {
+				if term == q || !strings.HasPrefix(term, q) {
+					continue
+				}
+				for book, weight := range books {
+					// префиксное совпадение ценится меньше точного
+					matched[book] = max(matched[book], float64(weight)*float64(len(q))/float64(len(term)))
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for book := range scores {
+			if score, ok := matched[book]; ok {
+				scores[book] += score
+			} else {
+				delete(scores, book)
+			}
+		}
+	}
+	return scores
+}
+
+// tokenize разбивает текст на нормализованные термы
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, stem(normalize(w)))
+	}
+	return terms
+}
+
+func normalize(word string) string {
+	return strings.Map(func(r rune) rune {
+		switch r = unicode.ToLower(r); r {
+		case 'ё':
+			return 'е'
+		case 'й':
+			return 'и'
+		default:
+			return r
+		}
+	}, word)
+}
+
+// окончания каждого языка отсортированы по убыванию длины, чтобы отрезалось самое длинное.
+// й заранее заменена на и в normalize
+var suffixes = []string{
+	// русские
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими",
+	"ая", "яя", "ое", "ее", "ые", "ие", "ои", "еи", "ыи", "ии", "ов", "ев", "ах", "ях", "ам", "ям", "ом", "ем", "ую", "юю",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь",
+	// английские
+	"ing", "es", "ed", "s",
+}
+
+// минимальная длина основы в рунах после отрезания окончания
+const minStem = 3
+
+// stem - простейший стеммер, отрезающий одно окончание слова
+func stem(word string) string {
+	runes := []rune(word)
+	for _, suffix := range suffixes {
+		n := len([]rune(suffix))
+		if len(runes)-n >= minStem && strings.HasSuffix(word, suffix) {
+			return string(runes[:len(runes)-n])
+		}
+	}
+	return word
+}
+
+func (s *serviceImpl) SearchUserBooks(ctx context.Context, params api.SearchUserBooksParams) ([]api.SearchResult, error) {
+	query := tokenize(params.Q)
+	if len(query) == 0 {
+		return nil, err(api.ErrorCodeEmptyQuery, "query %q contains no words", params.Q)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	books := s.users[params.UserID]
+	results := make([]api.SearchResult, 0)
+	for id, score := range s.index.search(params.UserID, query) {
+		results = append(results, api.SearchResult{Book: s.present(params.UserID, books[id]), Score: score})
+	}
+	slices.SortFunc(results, func(a, b api.SearchResult) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Book.ID, b.Book.ID)
+	})
+	if limit := params.Limit.Or(20); len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
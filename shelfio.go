@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/jx"
+
+	api "mws/gen_api"
+)
+
+// колонки CSV выгрузки, при импорте обязательна только id. В reads прочтения лежат JSON массивом,
+// иначе при выгрузке и загрузке обратно история перечитываний терялась бы
+var csvColumns = []string{"id", "title", "author", "published", "pages", "language", "page", "status", "finished", "isbn10", "isbn13", "reads"}
+
+func formatDate(date api.OptDate) string {
+	if d, ok := date.Get(); ok {
+		return d.Format(time.DateOnly)
+	}
+	return ""
+}
+
+func formatInt(v api.OptInt) string {
+	if i, ok := v.Get(); ok {
+		return strconv.Itoa(i)
+	}
+	return ""
+}
+
+func formatReads(reads []api.ReadThrough) string {
+	if len(reads) == 0 {
+		return ""
+	}
+	e := jx.GetEncoder()
+	defer jx.PutEncoder(e)
+	e.ArrStart()
+	for i := range reads {
+		reads[i].Encode(e)
+	}
+	e.ArrEnd()
+	return e.String()
+}
+
+func parseReads(v string) ([]api.ReadThrough, error) {
+	var reads []api.ReadThrough
+	e := jx.DecodeStr(v).Arr(func(d *jx.Decoder) error {
+		var r api.ReadThrough
+		if e := r.Decode(d); e != nil {
+			return e
+		}
+		reads = append(reads, r)
+		return nil
+	})
+	return reads, e
+}
+
+func bookToCSV(book *api.Book) []string {
+	return []string{
+		strconv.Itoa(book.ID),
+		book.Title,
+		book.Author,
+		formatDate(book.Published),
+		formatInt(book.Pages),
+		book.Language.Or(""),
+		strconv.Itoa(book.Page),
+		string(book.Status),
+		formatDate(book.Finished),
+		book.Isbn10.Or(""),
+		book.Isbn13.Or(""),
+		formatReads(book.Reads),
+	}
+}
+
+func writeCSV(w io.Writer, books []api.Book) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for i := range books {
+		if err := cw.Write(bookToCSV(&books[i])); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSONL(w io.Writer, books []api.Book) error {
+	bw := bufio.NewWriter(w)
+	for i := range books {
+		line, err := books[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		bw.Write(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func (s *serviceImpl) ExportUserBooks(ctx context.Context, params api.ExportUserBooksParams) (api.ExportUserBooksRes, error) {
+	// под мьютексом только копируем полку, чтобы медленный клиент не держал блокировку
+	s.mu.RLock()
+	books := make([]api.Book, 0, len(s.users[params.UserID]))
+	for _, book := range s.users[params.UserID] {
+		books = append(books, book)
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(books, func(a, b api.Book) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	// сгенерированный сервер закрывает Data после отправки, так что при обрыве
+	// соединения запись в pw завершится ошибкой и горутина не повиснет
+	pr, pw := io.Pipe()
+	switch params.Format.Or(api.ExportUserBooksFormatCsv) {
+	case api.ExportUserBooksFormatJsonl:
+		go func() { pw.CloseWithError(writeJSONL(pw, books)) }()
+		return &api.ExportUserBooksOKApplicationXNdjson{Data: pr}, nil
+	default:
+		go func() { pw.CloseWithError(writeCSV(pw, books)) }()
+		return &api.ExportUserBooksOKTextCsv{Data: pr}, nil
+	}
+}
+
+// importRow - прочитанная из файла книга вместе с номером строки для отчета
+type importRow struct {
+	row  int
+	book api.NewBook
+	// имена полок пользователя, на которые ставится книга, недостающие полки создаются
+	shelves []string
+}
+
+func rowError(report *api.ImportReport, row int, format string, args ...any) {
+	report.Errors = append(report.Errors, api.ImportRowError{Row: row, Message: fmt.Sprintf(format, args...)})
+}
+
+// readCSVRows читает книги из CSV, ошибки в отдельных строках попадают в отчет.
+// Возвращаемая ошибка означает что файл нельзя прочитать целиком
+func readCSVRows(r io.Reader, report *api.ImportReport) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.New(`CSV header has no "id" column`)
+	}
+
+	var rows []importRow
+	for n := 1; ; n++ {
+		record, err := cr.Read()
+		var parseErr *csv.ParseError
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		} else if errors.As(err, &parseErr) {
+			rowError(report, n, "%s", parseErr.Err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		optional := func(name string) api.OptString {
+			if v := field(name); v != "" {
+				return api.NewOptString(v)
+			}
+			return api.OptString{}
+		}
+
+		book := api.NewBook{
+			Title:    optional("title"),
+			Author:   optional("author"),
+			Language: optional("language"),
+			Isbn10:   optional("isbn10"),
+			Isbn13:   optional("isbn13"),
+		}
+		if book.ID, err = strconv.Atoi(field("id")); err != nil {
+			rowError(report, n, "invalid id %q", field("id"))
+			continue
+		}
+		if v := field("page"); v != "" {
+			page, err := strconv.Atoi(v)
+			if err != nil {
+				rowError(report, n, "invalid page %q", v)
+				continue
+			}
+			book.Page = api.NewOptInt(page)
+		}
+		if v := field("pages"); v != "" {
+			pages, err := strconv.Atoi(v)
+			if err != nil {
+				rowError(report, n, "invalid number of pages %q", v)
+				continue
+			}
+			book.Pages = api.NewOptInt(pages)
+		}
+		if v := field("published"); v != "" {
+			published, err := time.Parse(time.DateOnly, v)
+			if err != nil {
+				rowError(report, n, "invalid publication date %q", v)
+				continue
+			}
+			book.Published = api.NewOptDate(published)
+		}
+		if v := field("status"); v != "" {
+			var status api.ReadingStatus
+			if err := status.UnmarshalText([]byte(v)); err != nil {
+				rowError(report, n, "invalid status %q", v)
+				continue
+			}
+			book.Status = api.NewOptReadingStatus(status)
+		}
+		if v := field("finished"); v != "" {
+			finished, err := time.Parse(time.DateOnly, v)
+			if err != nil {
+				rowError(report, n, "invalid finish date %q", v)
+				continue
+			}
+			book.Finished = api.NewOptDate(finished)
+		}
+		if v := field("reads"); v != "" {
+			if book.Reads, err = parseReads(v); err != nil {
+				rowError(report, n, "invalid read-throughs: %s", err)
+				continue
+			}
+		}
+		rows = append(rows, importRow{row: n, book: book})
+	}
+}
+
+// readJSONLRows читает книги из JSON Lines, пустые строки пропускаются
+func readJSONLRows(r io.Reader, report *api.ImportReport) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	var rows []importRow
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var book api.NewBook
+		if err := book.UnmarshalJSON(line); err != nil {
+			rowError(report, n, "%s", err)
+			continue
+		}
+		rows = append(rows, importRow{row: n, book: book})
+	}
+	return rows, scanner.Err()
+}
+
+func (s *serviceImpl) ImportUserBooks(ctx context.Context, req api.ImportUserBooksReq, params api.ImportUserBooksParams) (*api.ImportReport, error) {
+	report := &api.ImportReport{
+		DryRun: params.DryRun.Or(false),
+		Errors: []api.ImportRowError{},
+	}
+
+	var rows []importRow
+	var e error
+	switch req := req.(type) {
+	case *api.ImportUserBooksReqTextCsv:
+		rows, e = readCSVRows(req, report)
+	case *api.ImportUserBooksReqApplicationXNdjson:
+		rows, e = readJSONLRows(req, report)
+	}
+	if e != nil {
+		return nil, err(api.ErrorCodeInvalidRequest, "%s", e)
+	}
+
+	if e := s.importBooks(ctx, params.UserID, rows, params.OnConflict.Or(api.ConflictPolicyFail), report); e != nil {
+		return nil, e
+	}
+	return report, nil
+}
+
+// importBooks проверяет прочитанные книги так же как AddUserBook и кладет их на полку, если это не пробный импорт.
+// При политике fail первая же книга, которая уже есть у пользователя, отменяет весь импорт
+func (s *serviceImpl) importBooks(ctx context.Context, userID int, rows []importRow, policy api.ConflictPolicy, report *api.ImportReport) error {
+	defer slices.SortStableFunc(report.Errors, func(a, b api.ImportRowError) int {
+		return cmp.Compare(a.Row, b.Row)
+	})
+
+	// проверяем до захвата мьютекса, так как можем сходить в каталог
+	type preparedRow struct {
+		row     int
+		book    api.Book
+		shelves []string
+	}
+	prepared := make([]preparedRow, 0, len(rows))
+	for _, row := range rows {
+		book, e := s.prepareBook(ctx, &row.book)
+		var p *problem
+		if errors.As(e, &p) {
+			rowError(report, row.row, "%s", p)
+			continue
+		} else if e != nil {
+			return e
+		}
+		prepared = append(prepared, preparedRow{row: row.row, book: book, shelves: row.shelves})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// книги, которые уже встречались в этом файле, конфликтуют так же как книги с полки
+	planned := make(map[int]bool)
+	accepted := make([]preparedRow, 0, len(prepared))
+	for _, p := range prepared {
+		_, onShelf := s.users[userID][p.book.ID]
+		if onShelf || planned[p.book.ID] {
+			switch policy {
+			case api.ConflictPolicySkip:
+				report.Skipped++
+				continue
+			case api.ConflictPolicyOverwrite:
+				report.Overwritten++
+			default:
+				return err(api.ErrorCodeBookExists, "row %d: user %d is already reading the book with id %d", p.row, userID, p.book.ID)
+			}
+		} else {
+			report.Added++
+		}
+		planned[p.book.ID] = true
+		accepted = append(accepted, p)
+	}
+
+	if !report.DryRun {
+		for _, p := range accepted {
+			s.putBook(userID, p.book)
+			s.shelveByName(userID, p.book.ID, p.shelves)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	api "mws/gen_api"
+)
+
+func TestCSVKeepsReads(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	ctx := context.Background()
+	date := func(year int, month time.Month, day int) api.OptDate {
+		return api.NewOptDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	reads := []api.ReadThrough{
+		{Started: date(2016, time.May, 1), Finished: date(2016, time.May, 15), Page: 374, Completed: true},
+		{Finished: date(2019, time.January, 3), Page: 374, Completed: true},
+		{Started: date(2024, time.March, 8), Page: 120},
+	}
+	_, e := s.AddUserBook(ctx, &api.NewBook{
+		ID:     1,
+		Title:  api.NewOptString("The Hunger Games"),
+		Author: api.NewOptString("Suzanne Collins"),
+		Pages:  api.NewOptInt(374),
+		Reads:  reads,
+	}, api.AddUserBookParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	res, e := s.ExportUserBooks(ctx, api.ExportUserBooksParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	data, e := io.ReadAll(res.(*api.ExportUserBooksOKTextCsv))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	report, e := s.ImportUserBooks(ctx, &api.ImportUserBooksReqTextCsv{Data: bytes.NewReader(data)}, api.ImportUserBooksParams{UserID: 2})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if report.Added != 1 || len(report.Errors) > 0 {
+		t.Fatalf("report = %+v\n%s", report, data)
+	}
+	book := s.users[2][1]
+	if !reflect.DeepEqual(book.Reads, reads) {
+		t.Errorf("reads after import = %+v, want %+v\n%s", book.Reads, reads, data)
+	}
+	if book.Status != api.ReadingStatusReading || book.Page != 120 {
+		t.Errorf("status %s, page %d, want the book being reread on page 120", book.Status, book.Page)
+	}
+}
+
+func TestCSVInvalidReads(t *testing.T) {
+	report := &api.ImportReport{}
+	rows, e := readCSVRows(strings.NewReader("id,title,reads\n1,a,\"[{\"\"page\"\": 1\"\n2,b,\n"), report)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(rows) != 1 || rows[0].book.ID != 2 || len(report.Errors) != 1 || report.Errors[0].Row != 1 {
+		t.Errorf("rows %+v, errors %+v, want row 1 rejected", rows, report.Errors)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	api "mws/gen_api"
+)
+
+// shelf - именованная полка пользователя. Книги хранятся по id, так что переименование
+// полки сразу видно во всех книгах на ней
+type shelf struct {
+	name  string
+	books map[int]struct{}
+}
+
+func (sh *shelf) info(id int) api.Shelf {
+	return api.Shelf{ID: id, Name: sh.name, Books: len(sh.books)}
+}
+
+// shelfKey - имя полки для сравнения без учета регистра
+func shelfKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// findShelf ищет полку пользователя по имени без учета регистра, возвращает 0 если ее нет. Вызывается под s.mu
+func (s *serviceImpl) findShelf(userID int, name string) int {
+	key := shelfKey(name)
+	for id, sh := range s.shelves[userID] {
+		if shelfKey(sh.name) == key {
+			return id
+		}
+	}
+	return 0
+}
+
+// checkShelfName нормализует имя полки и проверяет, что у пользователя нет другой полки с таким именем.
+// Вызывается под s.mu
+func (s *serviceImpl) checkShelfName(userID, shelfID int, name *string) error {
+	var c checks
+	if c.text("name", name); len(c) > 0 {
+		return c.problem()
+	}
+	if other := s.findShelf(userID, *name); other != 0 && other != shelfID {
+		return err(api.ErrorCodeShelfExists, "shelf %q already exists", s.shelves[userID][other].name)
+	}
+	return nil
+}
+
+// bookShelves возвращает имена полок, на которых стоит книга, по алфавиту. Вызывается под s.mu
+func (s *serviceImpl) bookShelves(userID, bookID int) []string {
+	var names []string
+	for _, sh := range s.shelves[userID] {
+		if _, ok := sh.books[bookID]; ok {
+			names = append(names, sh.name)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int { return cmp.Compare(shelfKey(a), shelfKey(b)) })
+	return names
+}
+
+// takeOffShelves убирает удаленную книгу со всех полок. Вызывается под s.mu
+func (s *serviceImpl) takeOffShelves(userID, bookID int) {
+	for _, sh := range s.shelves[userID] {
+		delete(sh.books, bookID)
+	}
+}
+
+func shelfNotFound(userID, shelfID int) *problem {
+	return err(api.ErrorCodeShelfNotFound, "shelf %d not found for user %d", shelfID, userID)
+}
+
+func (s *serviceImpl) GetUserShelves(ctx context.Context, params api.GetUserShelvesParams) ([]api.Shelf, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	shelves := make([]api.Shelf, 0, len(s.shelves[params.UserID]))
+	for id, sh := range s.shelves[params.UserID] {
+		shelves = append(shelves, sh.info(id))
+	}
+	slices.SortFunc(shelves, func(a, b api.Shelf) int {
+		return cmp.Or(cmp.Compare(shelfKey(a.Name), shelfKey(b.Name)), cmp.Compare(a.ID, b.ID))
+	})
+	return shelves, nil
+}
+
+func (s *serviceImpl) AddUserShelf(ctx context.Context, req *api.NewShelf, params api.AddUserShelfParams) (*api.Shelf, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.checkShelfName(params.UserID, 0, &req.Name); e != nil {
+		return nil, e
+	}
+
+	id := s.addShelf(params.UserID, req.Name)
+	info := s.shelves[params.UserID][id].info(id)
+	return &info, nil
+}
+
+// addShelf создает пустую полку с уже проверенным именем и возвращает ее id. Вызывается под s.mu
+func (s *serviceImpl) addShelf(userID int, name string) int {
+	if _, ok := s.shelves[userID]; !ok {
+		s.shelves[userID] = make(map[int]*shelf)
+	}
+	s.lastShelfID++
+	s.shelves[userID][s.lastShelfID] = &shelf{name: name, books: make(map[int]struct{})}
+	return s.lastShelfID
+}
+
+// shelveByName ставит книгу на полки с такими именами, полки, которых у пользователя нет, создаются.
+// Вызывается под s.mu
+func (s *serviceImpl) shelveByName(userID, bookID int, names []string) {
+	for _, name := range names {
+		id := s.findShelf(userID, name)
+		if id == 0 {
+			id = s.addShelf(userID, name)
+		}
+		s.shelves[userID][id].books[bookID] = struct{}{}
+	}
+}
+
+func (s *serviceImpl) RenameUserShelf(ctx context.Context, req *api.NewShelf, params api.RenameUserShelfParams) (*api.Shelf, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.shelves[params.UserID][params.ShelfID]
+	if !ok {
+		return nil, shelfNotFound(params.UserID, params.ShelfID)
+	}
+	if e := s.checkShelfName(params.UserID, params.ShelfID, &req.Name); e != nil {
+		return nil, e
+	}
+	sh.name = req.Name
+	info := sh.info(params.ShelfID)
+	return &info, nil
+}
+
+func (s *serviceImpl) RemoveUserShelf(ctx context.Context, params api.RemoveUserShelfParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.shelves[params.UserID][params.ShelfID]; !ok {
+		return shelfNotFound(params.UserID, params.ShelfID)
+	}
+	delete(s.shelves[params.UserID], params.ShelfID)
+	return nil
+}
+
+func (s *serviceImpl) AddBookToShelf(ctx context.Context, params api.AddBookToShelfParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.shelves[params.UserID][params.ShelfID]
+	if !ok {
+		return shelfNotFound(params.UserID, params.ShelfID)
+	}
+	if e := s.checkBook(params.UserID, params.BookID); e != nil {
+		return e
+	}
+	sh.books[params.BookID] = struct{}{}
+	return nil
+}
+
+func (s *serviceImpl) RemoveBookFromShelf(ctx context.Context, params api.RemoveBookFromShelfParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.shelves[params.UserID][params.ShelfID]
+	if !ok {
+		return shelfNotFound(params.UserID, params.ShelfID)
+	}
+	if _, ok := sh.books[params.BookID]; !ok {
+		return err(api.ErrorCodeBookNotOnShelf, "book %d is not on shelf %q", params.BookID, sh.name)
+	}
+	delete(sh.books, params.BookID)
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// Статистика считается не по текущей странице книги, а по истории: прочитанные книги - по завершенным
+// прочтениям, страницы - по записям о продвижении, которые делаются при каждом обновлении прогресса
+
+// pageEvent - запись о том, сколько страниц книги прочитано при обновлении прогресса
+type pageEvent struct {
+	at     time.Time
+	bookID int
+	pages  int
+}
+
+// сколько авторов попадает в статистику
+const topAuthorsLimit = 10
+
+// pagesDone возвращает, сколько страниц прочитано в текущем прочтении книги, для прочитанной книги - всю ее длину
+func pagesDone(book *api.Book) int {
+	pages, known := book.Pages.Get()
+	if activeRead(book) == nil {
+		if book.Status != api.ReadingStatusRead {
+			return 0
+		} else if known {
+			return pages
+		}
+	} else if read, ok := progress(book); ok && known {
+		return int(math.Round(read * float64(pages)))
+	}
+	if unit, _, _ := bookUnit(book); unit == api.ProgressUnitPage {
+		return book.Page - firstPosition(unit)
+	}
+	return 0
+}
+
+// logPages записывает страницы, прочитанные между двумя состояниями книги. Новое прочтение считается
+// с нуля, откат назад не записывается. Вызывается под s.mu
+func (s *serviceImpl) logPages(userID int, before, after *api.Book) {
+	base := 0
+	if activeRead(before) != nil {
+		base = pagesDone(before)
+	} else if before.Status == api.ReadingStatusRead && activeRead(after) == nil {
+		return
+	}
+	if pages := pagesDone(after) - base; pages > 0 {
+		s.pageLog[userID] = append(s.pageLog[userID], pageEvent{at: time.Now(), bookID: after.ID, pages: pages})
+	}
+}
+
+// dropPages удаляет историю чтения книги вместе с ней. Вызывается под s.mu
+func (s *serviceImpl) dropPages(userID, bookID int) {
+	s.pageLog[userID] = slices.DeleteFunc(s.pageLog[userID], func(e pageEvent) bool { return e.bookID == bookID })
+}
+
+func dayOf(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// daysBetween считает дни чтения от start до finish включительно
+func daysBetween(start, finish time.Time) int {
+	a, _ := time.Parse(time.DateOnly, dayOf(start))
+	b, _ := time.Parse(time.DateOnly, dayOf(finish))
+	return int(b.Sub(a).Hours()/24) + 1
+}
+
+// finishedRead - завершенное прочтение книги с известной датой окончания
+type finishedRead struct {
+	book *api.Book
+	read *api.ReadThrough
+	day  string
+}
+
+// finishedReads собирает завершенные прочтения пользователя, прочтения без даты окончания не учитываются. Вызывается под s.mu
+func (s *serviceImpl) finishedReads(userID int) []finishedRead {
+	var reads []finishedRead
+	for _, book := range s.users[userID] {
+		for i := range book.Reads {
+			if r := &book.Reads[i]; r.Completed && r.Finished.Set {
+				reads = append(reads, finishedRead{book: &book, read: r, day: dayOf(r.Finished.Value)})
+			}
+		}
+	}
+	return reads
+}
+
+// countBy превращает счетчики в записи ответа в порядке возрастания ключа
+func countBy[T any](keys map[string]int, entry func(key string, n int) T) []T {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+	result := make([]T, 0, len(sorted))
+	for _, key := range sorted {
+		result = append(result, entry(key, keys[key]))
+	}
+	return result
+}
+
+func (s *serviceImpl) GetUserStats(ctx context.Context, params api.GetUserStatsParams) (*api.ReadingStats, error) {
+	tz := params.Tz.Or("UTC")
+	loc, e := time.LoadLocation(tz)
+	if e != nil {
+		return nil, err(api.ErrorCodeUnknownTimeZone, "unknown time zone %q", tz)
+	}
+	now := time.Now().In(loc)
+	from := ""
+	if date, ok := params.From.Get(); ok {
+		from = dayOf(date)
+	}
+	to := dayOf(params.To.Or(now))
+	if from > to {
+		return nil, err(api.ErrorCodeInvalidRange, "range starts on %s after it ends on %s", from, to)
+	}
+	inRange := func(day string) bool { return from <= day && day <= to }
+	year := strconv.Itoa(now.Year())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.users[params.UserID]; !ok {
+		return nil, err(api.ErrorCodeUserNotFound, "user %d not found", params.UserID)
+	}
+
+	stats := api.ReadingStats{
+		From:        params.From,
+		To:          params.To.Or(now),
+		TimeZone:    tz,
+		TopAuthors:  []api.AuthorCount{},
+		CurrentYear: api.YearTotals{Year: now.Year()},
+	}
+
+	byMonth, byYear := make(map[string]int), make(map[string]int)
+	byAuthor := make(map[int]int)
+	var days, daysCount, length, lengthCount int
+	for _, f := range s.finishedReads(params.UserID) {
+		if f.day[:4] == year {
+			stats.CurrentYear.Books++
+		}
+		if !inRange(f.day) {
+			continue
+		}
+		byMonth[f.day[:7]]++
+		byYear[f.day[:4]]++
+		if f.read.Started.Set {
+			days += daysBetween(f.read.Started.Value, f.read.Finished.Value)
+			daysCount++
+		}
+		if pages, ok := f.book.Pages.Get(); ok {
+			length += pages
+			lengthCount++
+		}
+		for _, c := range f.book.Contributors {
+			if c.Role == api.ContributorRoleAuthor {
+				byAuthor[c.AuthorID]++
+			}
+		}
+	}
+
+	byDay := make(map[string]int)
+	pages := 0
+	for _, event := range s.pageLog[params.UserID] {
+		day := dayOf(event.at.In(loc))
+		if day[:4] == year {
+			stats.CurrentYear.Pages += event.pages
+		}
+		if inRange(day) {
+			byDay[day] += event.pages
+			pages += event.pages
+		}
+	}
+
+	stats.FinishedByMonth = countBy(byMonth, func(month string, n int) api.MonthCount {
+		return api.MonthCount{Month: month, Books: n}
+	})
+	stats.FinishedByYear = countBy(byYear, func(year string, n int) api.YearCount {
+		y, _ := strconv.Atoi(year)
+		return api.YearCount{Year: y, Books: n}
+	})
+	stats.PagesByDay = countBy(byDay, func(day string, n int) api.DayPages {
+		date, _ := time.Parse(time.DateOnly, day)
+		return api.DayPages{Date: date, Pages: n}
+	})
+	if len(byDay) > 0 {
+		stats.PagesPerDay = float64(pages) / float64(len(byDay))
+	}
+	if daysCount > 0 {
+		stats.DaysPerBook = api.NewOptFloat64(float64(days) / float64(daysCount))
+	}
+	if lengthCount > 0 {
+		stats.AverageLength = api.NewOptFloat64(float64(length) / float64(lengthCount))
+	}
+
+	for id, n := range byAuthor {
+		if author, ok := s.authors.authors[id]; ok {
+			stats.TopAuthors = append(stats.TopAuthors, api.AuthorCount{AuthorID: id, Name: author.Name, Books: n})
+		}
+	}
+	slices.SortFunc(stats.TopAuthors, func(a, b api.AuthorCount) int {
+		return cmp.Or(cmp.Compare(b.Books, a.Books), cmp.Compare(a.Name, b.Name))
+	})
+	stats.TopAuthors = stats.TopAuthors[:min(len(stats.TopAuthors), topAuthorsLimit)]
+	return &stats, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "mws/gen_api"
+)
+
+func TestUserStatsUnknownUser(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	ctx := context.Background()
+	if _, e := s.AddUserBook(ctx, &api.NewBook{ID: 1, Title: api.NewOptString("Dracula"), Author: api.NewOptString("Bram Stoker")},
+		api.AddUserBookParams{UserID: 1}); e != nil {
+		t.Fatal(e)
+	}
+
+	// у пользователя без прочитанных книг статистика пустая, а не ошибка
+	stats, e := s.GetUserStats(ctx, api.GetUserStatsParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(stats.FinishedByMonth) != 0 {
+		t.Errorf("finished by month = %v, want none", stats.FinishedByMonth)
+	}
+
+	_, e = s.GetUserStats(ctx, api.GetUserStatsParams{UserID: 2})
+	var p *problem
+	if !errors.As(e, &p) || p.code != api.ErrorCodeUserNotFound {
+		t.Errorf("error %v, want user_not_found", e)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ogen-go/ogen/validate"
+	"golang.org/x/text/unicode/norm"
+
+	api "mws/gen_api"
+)
+
+// Правила для присланных данных. Диапазоны id и длины строк описаны в api.yml и проверяются сгенерированным
+// сервером, но книги и отрывки из импорта приходят в обход него, поэтому bookFromRequest и highlightFromRequest
+// проверяют их тем же Validate.
+// Здесь то, чего схема выразить не может: нормализация текста, строки из одних пробелов и даты из будущего
+
+// cleanText приводит строку из запроса к виду, в котором она хранится: NFC и без пробелов по краям,
+// иначе одинаково выглядящие названия не совпадут при поиске и сравнении
+func cleanText(s string) string {
+	return strings.TrimSpace(norm.NFC.String(s))
+}
+
+// checks накапливает ошибки полей, чтобы клиент узнал обо всех сразу
+type checks []fieldProblem
+
+func (c *checks) add(field, format string, args ...any) {
+	*c = append(*c, fieldProblem{field, text{format, args}})
+}
+
+// text нормализует строку и проверяет, что от нее что-то осталось
+func (c *checks) text(field string, value *string) {
+	if *value = cleanText(*value); *value == "" {
+		c.add(field, "%s must not be blank", field)
+	}
+}
+
+func (c *checks) optText(field string, value *api.OptString) {
+	if value.Set {
+		c.text(field, &value.Value)
+	}
+}
+
+// notFuture проверяет, что дата уже наступила хотя бы где-то: раньше всего сутки начинаются в поясе UTC+14
+func (c *checks) notFuture(field string, date api.OptDate) {
+	if date.Set && dayOf(date.Value) > dayOf(time.Now().UTC().Add(14*time.Hour)) {
+		c.add(field, "%s %s is in the future", field, dayOf(date.Value))
+	}
+}
+
+// problem возвращает ошибку со всеми найденными нарушениями или nil, если их нет
+func (c checks) problem() error {
+	if len(c) == 0 {
+		return nil
+	}
+	// detail - сообщения всех полей через точку с запятой, каждое переводится отдельно
+	formats := make([]string, 0, len(c))
+	messages := make([]any, 0, len(c))
+	for _, f := range c {
+		formats = append(formats, "%s")
+		messages = append(messages, f.message)
+	}
+	p := invalid(strings.Join(formats, "; "), messages...)
+	p.fields = c
+	return p
+}
+
+// schemaChecks переводит ошибки проверки по api.yml в ошибки полей, имена вложенных полей собираются через точку
+func schemaChecks(prefix string, schema *validate.Error) checks {
+	var c checks
+	for _, f := range schema.Fields {
+		var nested *validate.Error
+		if errors.As(f.Error, &nested) {
+			c = append(c, schemaChecks(prefix+f.Name+".", nested)...)
+		} else {
+			c.add(prefix+f.Name, "%s%s: %s", prefix, f.Name, f.Error)
+		}
+	}
+	return c
+}
+
+// checkSchema проверяет ограничения api.yml для данных, которые пришли не через сгенерированный сервер
+func checkSchema(v interface{ Validate() error }) error {
+	var schema *validate.Error
+	if e := v.Validate(); errors.As(e, &schema) {
+		return schemaChecks("", schema).problem()
+	} else if e != nil {
+		return e
+	}
+	return nil
+}
+
+// checkNewBook проверяет и нормализует книгу из запроса или из строки импорта
+func checkNewBook(req *api.NewBook) error {
+	if e := checkSchema(req); e != nil {
+		return e
+	}
+
+	var c checks
+	c.optText("title", &req.Title)
+	c.optText("author", &req.Author)
+	for i := range req.Contributors {
+		c.text(fmt.Sprintf("contributors.%d.name", i), &req.Contributors[i].Name)
+	}
+	if req.Series.Set {
+		c.text("series.name", &req.Series.Value.Name)
+	}
+	c.notFuture("published", req.Published)
+	c.notFuture("finished", req.Finished)
+	return c.problem()
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	api "mws/gen_api"
+)
+
+// problemFields возвращает поля, на которые жалуется ошибка, или nil, если ошибки нет
+func problemFields(t *testing.T, e error) []string {
+	t.Helper()
+	if e == nil {
+		return nil
+	}
+	var p *problem
+	if !errors.As(e, &p) {
+		t.Fatalf("error %v is not a problem", e)
+	}
+	if p.code != api.ErrorCodeInvalidRequest {
+		t.Errorf("code = %s, want invalid_request", p.code)
+	}
+	fields := []string{}
+	for _, f := range p.fields {
+		fields = append(fields, f.field)
+	}
+	return fields
+}
+
+func TestCleanText(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"Ёжик в тумане", "Ёжик в тумане"},
+		// Е и комбинируемый диерезис склеиваются в Ё
+		{"Е\u0308жик", "Ёжик"},
+		{"  Dracula\t\n", "Dracula"},
+		{"Cafe\u0301 ", "Café"},
+		{" \t ", ""},
+	} {
+		if got := cleanText(tt.in); got != tt.want {
+			t.Errorf("cleanText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChecksText(t *testing.T) {
+	var c checks
+	value := " Е\u0308жик "
+	c.text("title", &value)
+	if len(c) != 0 || value != "Ёжик" {
+		t.Errorf("value %q, checks %v, want normalized value without errors", value, c)
+	}
+
+	blank := "  \t"
+	c.text("author", &blank)
+	missing := api.OptString{}
+	c.optText("series", &missing)
+	if len(c) != 1 || c[0].field != "author" {
+		t.Errorf("checks = %v, want only blank author", c)
+	}
+	if e := c.problem(); e == nil || e.Error() != "author must not be blank" {
+		t.Errorf("problem = %v", e)
+	}
+}
+
+func TestNotFuture(t *testing.T) {
+	// раньше всех новый день наступает в UTC+14, дата, которая там уже идет, не считается будущей
+	edge := time.Now().UTC().Add(14 * time.Hour)
+	edgeDay := time.Date(edge.Year(), edge.Month(), edge.Day(), 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		date   time.Time
+		future bool
+	}{
+		{time.Date(1897, time.May, 26, 0, 0, 0, 0, time.UTC), false},
+		{time.Now().UTC(), false},
+		{edgeDay, false},
+		{edgeDay.AddDate(0, 0, 1), true},
+		{edgeDay.AddDate(1, 0, 0), true},
+	} {
+		var c checks
+		c.notFuture("finished", api.NewOptDate(tt.date))
+		if future := len(c) > 0; future != tt.future {
+			t.Errorf("%s: future = %v, want %v", dayOf(tt.date), future, tt.future)
+		}
+	}
+
+	var c checks
+	c.notFuture("finished", api.OptDate{})
+	if len(c) != 0 {
+		t.Errorf("missing date is reported: %v", c)
+	}
+}
+
+func TestCheckNewBook(t *testing.T) {
+	tomorrow := api.NewOptDate(time.Now().UTC().AddDate(0, 0, 2))
+	for _, tt := range []struct {
+		name   string
+		book   api.NewBook
+		fields []string
+	}{
+		{
+			name: "valid",
+			book: api.NewBook{ID: 1, Title: api.NewOptString("Dracula"), Author: api.NewOptString("Bram Stoker")},
+		},
+		{
+			name:   "zero id",
+			book:   api.NewBook{ID: 0, Title: api.NewOptString("Dracula")},
+			fields: []string{"id"},
+		},
+		{
+			name:   "long title",
+			book:   api.NewBook{ID: 1, Title: api.NewOptString(strings.Repeat("я", 501))},
+			fields: []string{"title"},
+		},
+		{
+			name:   "empty author",
+			book:   api.NewBook{ID: 1, Author: api.NewOptString("")},
+			fields: []string{"author"},
+		},
+		{
+			name:   "blank title",
+			book:   api.NewBook{ID: 1, Title: api.NewOptString("   ")},
+			fields: []string{"title"},
+		},
+		{
+			name: "blank contributor",
+			book: api.NewBook{ID: 1, Contributors: []api.NewContributor{
+				{Name: "Bram Stoker"},
+				{Name: " "},
+			}},
+			fields: []string{"contributors.1.name"},
+		},
+		{
+			name:   "finished in the future",
+			book:   api.NewBook{ID: 1, Finished: tomorrow},
+			fields: []string{"finished"},
+		},
+		{
+			name: "all errors at once",
+			book: api.NewBook{
+				ID:       1,
+				Title:    api.NewOptString(" "),
+				Author:   api.NewOptString("\t"),
+				Finished: tomorrow,
+			},
+			fields: []string{"title", "author", "finished"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := problemFields(t, checkNewBook(&tt.book)); !slices.Equal(got, tt.fields) {
+				t.Errorf("fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+
+	book := api.NewBook{ID: 1, Title: api.NewOptString(" Е\u0308жик в тумане "), Author: api.NewOptString("Сергей Козлов")}
+	if e := checkNewBook(&book); e != nil {
+		t.Fatal(e)
+	}
+	if book.Title.Value != "Ёжик в тумане" {
+		t.Errorf("title = %q, want it trimmed and in NFC", book.Title.Value)
+	}
+}
+
+// Строки импорта не проходят через сгенерированный сервер, поэтому ограничения api.yml проверяет сам импорт
+func TestImportChecksSchema(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	lines := []string{
+		`{"id": 1, "title": "Dracula", "author": "Bram Stoker"}`,
+		fmt.Sprintf(`{"id": 2, "title": %q}`, strings.Repeat("a", 501)),
+		`{"id": 0, "title": "Zero"}`,
+		`{"id": 3, "title": "Edition", "edition": 0}`,
+		fmt.Sprintf(`{"id": 4, "title": "Language", "language": %q}`, strings.Repeat("x", 36)),
+		`{"id": 5, "title": "Е\u0308жик", "author": "Сергей Козлов"}`,
+	}
+	report, e := s.ImportUserBooks(context.Background(),
+		&api.ImportUserBooksReqApplicationXNdjson{Data: strings.NewReader(strings.Join(lines, "\n"))},
+		api.ImportUserBooksParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	var rows []int
+	for _, re := range report.Errors {
+		rows = append(rows, re.Row)
+	}
+	if !slices.Equal(rows, []int{2, 3, 4, 5}) || report.Added != 2 {
+		t.Errorf("added %d, errors %+v, want rows 2-5 rejected", report.Added, report.Errors)
+	}
+	if title := s.users[1][5].Title; title != "Ёжик" {
+		t.Errorf("title = %q, want NFC", title)
+	}
+}
+
+func TestKindleImportChecksSchema(t *testing.T) {
+	s := newServiceImpl(nil, &memoryBlobs{blobs: make(map[string][]byte)})
+	clipping := func(title, text string) string {
+		return title + " (Author)\n- Your Highlight on Location 10-12 | Added on Sunday, 15 May 2016 20:33:15\n\n" + text + "\n==========\n"
+	}
+	file := clipping(strings.Repeat("t", 501), "text") +
+		clipping("Long highlight", strings.Repeat("h", 10001)) +
+		clipping("Cafe\u0301", "  ok  ")
+	report, e := s.ImportKindleClippings(context.Background(), api.ImportKindleClippingsReq{Data: strings.NewReader(file)},
+		api.ImportKindleClippingsParams{UserID: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	var rows []int
+	for _, re := range report.Errors {
+		rows = append(rows, re.Row)
+	}
+	if !slices.Equal(rows, []int{1, 2}) || report.Added != 1 || report.Highlights.Value != 1 {
+		t.Fatalf("report = %+v, want long title and long highlight rejected", report)
+	}
+	for id, book := range s.users[1] {
+		if book.Title != "Café" {
+			t.Errorf("title = %q, want NFC", book.Title)
+		}
+		if h := s.annotations[1][id].highlights; len(h) != 1 || h[0].Text != "ok" {
+			t.Errorf("highlights = %+v", h)
+		}
+	}
+}